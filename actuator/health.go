@@ -0,0 +1,87 @@
+package actuator
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/web"
+)
+
+// Health statuses reported by indicators.
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
+// Check is the result of one health indicator.
+type Check struct {
+	Name    string         `json:"name"`
+	Status  string         `json:"status"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Indicator reports the health of one component.
+type Indicator func() Check
+
+// readinessHandler runs each indicator and reports DOWN (503) if any of
+// them is down, so orchestrators stop routing traffic to this instance.
+func readinessHandler(indicators []Indicator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		status, code := StatusUp, http.StatusOK
+		checks := make([]Check, 0, len(indicators))
+		for _, ind := range indicators {
+			check := ind()
+			if check.Status != StatusUp {
+				status, code = StatusDown, http.StatusServiceUnavailable
+			}
+			checks = append(checks, check)
+		}
+		ctx.JSON(code, gin.H{
+			"status": status,
+			"checks": checks,
+		})
+	}
+}
+
+// shutdownIndicator is DOWN once the app has begun shutting down.
+func shutdownIndicator(draining *atomic.Bool) Indicator {
+	return func() Check {
+		if draining.Load() {
+			return Check{Name: "shutdown", Status: StatusDown, Details: map[string]any{"draining": true}}
+		}
+		return Check{Name: "shutdown", Status: StatusUp}
+	}
+}
+
+// webIndicator is DOWN until the web server has bound its listener, and
+// again once it starts draining.
+func webIndicator(listening web.ListeningFunc) Indicator {
+	return func() Check {
+		addr, ok := listening()
+		if !ok {
+			return Check{Name: "web", Status: StatusDown, Details: map[string]any{"listening": false}}
+		}
+		return Check{Name: "web", Status: StatusUp, Details: map[string]any{"addr": addr.String()}}
+	}
+}
+
+// configIndicator is DOWN while the last config reload failed, meaning the
+// instance is still serving the previous, stale config.
+func configIndicator(mgr *config.Manager) Indicator {
+	return func() Check {
+		last := mgr.LastReload()
+		check := Check{Name: "config", Status: StatusUp, Details: map[string]any{}}
+		if !last.Time.IsZero() {
+			check.Details["lastReload"] = last.Time.UTC().Format(time.RFC3339)
+		}
+		if last.Err != nil {
+			check.Status = StatusDown
+			check.Details["error"] = last.Err.Error()
+		}
+		return check
+	}
+}
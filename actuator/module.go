@@ -2,9 +2,12 @@ package actuator
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,23 +15,77 @@ import (
 
 	"github.com/skekre98/genever/config"
 	"github.com/skekre98/genever/core"
+	"github.com/skekre98/genever/flags"
+	"github.com/skekre98/genever/metrics"
 	"github.com/skekre98/genever/web"
 )
 
 const Name = "actuator"
 
-type module struct{}
+type module struct {
+	opts     options
+	draining atomic.Bool
+}
+
+type options struct {
+	defaultRegistry bool
+	readiness       []Indicator
+}
+
+// Option configures the actuator module.
+type Option func(*options)
+
+// WithDefaultRegistry serves the global prometheus.DefaultRegisterer at
+// /metrics instead of the actuator's own registry, for apps that already
+// register their metrics globally. Metrics registered through the metrics
+// package aren't served then.
+func WithDefaultRegistry() Option {
+	return func(o *options) { o.defaultRegistry = true }
+}
+
+// WithReadiness registers indicators that must all be UP for
+// {basePath}/health/readiness to report the instance ready, e.g. checks
+// of dependencies the app can't serve without.
+func WithReadiness(indicators ...Indicator) Option {
+	return func(o *options) { o.readiness = append(o.readiness, indicators...) }
+}
 
-func Module() core.Module { return &module{} }
+// Module returns the actuator module.
+//
+// Metrics are served from the shared registry of the metrics package, which
+// other modules register on with metrics.Counter and friends (or directly,
+// via metrics.Registry). An own registry avoids collisions with metrics
+// registered globally by the host app or libraries.
+func Module(opts ...Option) core.Module {
+	m := &module{}
+	for _, o := range opts {
+		o(&m.opts)
+	}
+	return m
+}
 
 func (m *module) Name() string        { return Name }
 func (m *module) DependsOn() []string { return []string{web.Name} }
 
+// Requires declares what Configure gets from the container; the engine
+// comes from the web module.
+func (m *module) Requires() []any {
+	return []any{core.TypeKey[config.Root]{}, core.TypeKey[*gin.Engine]{}}
+}
+
+// OptionalDependsOn configures the actuator after the flags module, if
+// present, so it can serve /features.
+func (m *module) OptionalDependsOn() []string { return []string{flags.Name} }
+
 func (m *module) Configure(c core.Container) error {
 	engine := web.Engine(c)
 	cfg := core.Get[config.Root](c)
 
-	group := engine.Group(cfg.Actuator.BasePath)
+	basePath, err := normalizeBasePath(cfg.Actuator.BasePath)
+	if err != nil {
+		return err
+	}
+	group := engine.Group(basePath)
 
 	// Health
 	group.GET("/health", func(ctx *gin.Context) {
@@ -38,6 +95,40 @@ func (m *module) Configure(c core.Container) error {
 		})
 	})
 
+	// Readiness: indicators for components that can make this instance
+	// unfit to serve traffic while it's still alive.
+	indicators := []Indicator{shutdownIndicator(&m.draining)}
+	if v, ok := c.Get(core.TypeKey[web.ListeningFunc]{}); ok {
+		indicators = append(indicators, webIndicator(v.(web.ListeningFunc)))
+	}
+	if v, ok := c.Get(core.TypeKey[*config.Manager]{}); ok {
+		indicators = append(indicators, configIndicator(v.(*config.Manager)))
+	}
+	indicators = append(indicators, m.opts.readiness...)
+	group.GET("/health/readiness", readinessHandler(indicators))
+
+	// Config reload: pause background reloads, e.g. for a maintenance
+	// window, and resume them. Like the other endpoints these aren't
+	// authenticated; expose the actuator on an internal network only.
+	if v, ok := c.Get(core.TypeKey[*config.Manager]{}); ok {
+		mgr := v.(*config.Manager)
+		reloadStatus := func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"paused": mgr.ReloadPaused()})
+		}
+		group.GET("/config/reload", reloadStatus)
+		group.POST("/config/reload/pause", func(ctx *gin.Context) {
+			mgr.PauseReload()
+			reloadStatus(ctx)
+		})
+		group.POST("/config/reload/resume", func(ctx *gin.Context) {
+			if err := mgr.ResumeReload(); err != nil {
+				web.Problem(ctx, http.StatusInternalServerError, "reload failed: "+err.Error())
+				return
+			}
+			reloadStatus(ctx)
+		})
+	}
+
 	// Info
 	group.GET("/info", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{
@@ -54,13 +145,84 @@ func (m *module) Configure(c core.Container) error {
 		})
 	})
 
+	// Mappings: every route registered on the engine, read per request so
+	// routes added after Configure are listed too.
+	group.GET("/mappings", func(ctx *gin.Context) {
+		out := []gin.H{}
+		for _, r := range engine.Routes() {
+			out = append(out, gin.H{
+				"method":  r.Method,
+				"path":    r.Path,
+				"handler": r.Handler,
+			})
+		}
+		ctx.JSON(http.StatusOK, gin.H{"routes": out})
+	})
+
+	// Features: the current feature flags, as handlers see them
+	if v, ok := c.Get(core.TypeKey[*flags.Flags]{}); ok {
+		features := v.(*flags.Flags)
+		group.GET("/features", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"features": features.All()})
+		})
+	}
+
+	// Slow log
+	if v, ok := c.Get(core.TypeKey[web.SlowLogFunc]{}); ok {
+		entries := v.(web.SlowLogFunc)
+		group.GET("/slowlog", func(ctx *gin.Context) {
+			out := []gin.H{}
+			for _, e := range entries() {
+				out = append(out, gin.H{
+					"method":     e.Method,
+					"path":       e.Path,
+					"status":     e.Status,
+					"duration":   e.Duration.String(),
+					"durationMs": e.Duration.Milliseconds(),
+					"time":       e.Time.UTC().Format(time.RFC3339),
+				})
+			}
+			ctx.JSON(http.StatusOK, gin.H{"requests": out})
+		})
+	}
+
 	// Metrics
 	if cfg.Observability.Metrics.Enabled {
-		group.GET("/metrics", gin.WrapH(promhttp.Handler()))
+		group.GET("/metrics", gin.WrapH(m.metricsHandler(c)))
 	}
 
 	return nil
 }
 
+// metricsHandler serves the registry chosen by the module options.
+func (m *module) metricsHandler(c core.Container) http.Handler {
+	if m.opts.defaultRegistry {
+		return promhttp.Handler()
+	}
+	return promhttp.HandlerFor(metrics.Registry(c), promhttp.HandlerOpts{})
+}
+
+// normalizeBasePath returns p with a single leading slash and no trailing
+// slash, so "actuator", "/actuator" and "/actuator/" all mount the same
+// routes. An empty path or "/" mounts at the root.
+func normalizeBasePath(p string) (string, error) {
+	trimmed := strings.Trim(p, "/")
+	if strings.ContainsAny(trimmed, " \t\n?#*:") || strings.Contains(trimmed, "//") {
+		return "", fmt.Errorf("actuator: invalid base path %q", p)
+	}
+	if trimmed == "" {
+		return "", nil
+	}
+	return "/" + trimmed, nil
+}
+
 func (m *module) Start(_ context.Context, _ core.Container) error { return nil }
+
+// PreStop marks the instance not ready, so load balancers stop routing to
+// it while the web server drains.
+func (m *module) PreStop(_ context.Context, _ core.Container) error {
+	m.draining.Store(true)
+	return nil
+}
+
 func (m *module) Stop(_ context.Context, _ core.Container) error  { return nil }
@@ -0,0 +1,505 @@
+package actuator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/core"
+	"github.com/skekre98/genever/flags"
+	"github.com/skekre98/genever/metrics"
+	"github.com/skekre98/genever/web"
+)
+
+// newTestContainer seeds a container with what the actuator needs from the
+// web module and the app.
+func newTestContainer(root config.Root) (core.Container, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	c := core.NewContainer()
+	core.Put(c, engine)
+	core.Put(c, root)
+	return c, engine
+}
+
+func get(engine *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestModule_BasePathNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		health   string
+	}{
+		{"valid path", "/actuator", "/actuator/health"},
+		{"missing leading slash", "actuator", "/actuator/health"},
+		{"trailing slash", "/actuator/", "/actuator/health"},
+		{"nested path", "/ops/actuator/", "/ops/actuator/health"},
+		{"root", "/", "/health"},
+		{"empty", "", "/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, engine := newTestContainer(config.Root{
+				Actuator: config.ActuatorConfig{BasePath: tt.basePath},
+			})
+			if err := Module().Configure(c); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			if w := get(engine, tt.health); w.Code != http.StatusOK {
+				t.Errorf("GET %s = %d, want %d", tt.health, w.Code, http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestModule_InvalidBasePath(t *testing.T) {
+	for _, basePath := range []string{"/act uator", "/actuator?x=1", "/a//b", "/:id", "/*all"} {
+		t.Run(basePath, func(t *testing.T) {
+			c, _ := newTestContainer(config.Root{
+				Actuator: config.ActuatorConfig{BasePath: basePath},
+			})
+			if err := Module().Configure(c); err == nil {
+				t.Errorf("Configure() with base path %q: expected error", basePath)
+			}
+		})
+	}
+}
+
+func TestModule_SlowLogEndpoint(t *testing.T) {
+	c, engine := newTestContainer(config.Root{
+		Actuator: config.ActuatorConfig{BasePath: "/actuator"},
+	})
+	core.Put(c, web.SlowLogFunc(func() []web.SlowEntry {
+		return []web.SlowEntry{{
+			Method:   http.MethodGet,
+			Path:     "/reports",
+			Status:   http.StatusOK,
+			Duration: 1500 * time.Millisecond,
+			Time:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}}
+	}))
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	w := get(engine, "/actuator/slowlog")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /actuator/slowlog = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var body struct {
+		Requests []struct {
+			Path       string `json:"path"`
+			Duration   string `json:"duration"`
+			DurationMs int64  `json:"durationMs"`
+		} `json:"requests"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if len(body.Requests) != 1 || body.Requests[0].Path != "/reports" ||
+		body.Requests[0].Duration != "1.5s" || body.Requests[0].DurationMs != 1500 {
+		t.Errorf("slowlog body = %s", w.Body.String())
+	}
+}
+
+func TestModule_SlowLogEndpointDisabled(t *testing.T) {
+	c, engine := newTestContainer(config.Root{})
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if w := get(engine, "/slowlog"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /slowlog without slow log = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestModule_FeaturesEndpoint(t *testing.T) {
+	c, engine := newTestContainer(config.Root{
+		Features: config.FeaturesConfig{Flags: map[string]bool{"new-checkout": true, "beta": false}},
+	})
+	if err := flags.Module().Configure(c); err != nil {
+		t.Fatalf("flags Configure() error = %v", err)
+	}
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	w := get(engine, "/features")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /features = %d, want %d", w.Code, http.StatusOK)
+	}
+	var body struct {
+		Features map[string]bool `json:"features"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Features) != 2 || !body.Features["new-checkout"] || body.Features["beta"] {
+		t.Errorf("features = %v, want new-checkout on and beta off", body.Features)
+	}
+}
+
+func TestModule_FeaturesEndpointWithoutFlags(t *testing.T) {
+	c, engine := newTestContainer(config.Root{})
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if w := get(engine, "/features"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /features without the flags module = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestModule_MappingsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := core.NewContainer()
+	core.Put(c, config.Root{
+		Server:   config.ServerConfig{Addr: "127.0.0.1:0", Mode: gin.TestMode},
+		Actuator: config.ActuatorConfig{BasePath: "/actuator"},
+	})
+	core.Put(c, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	routes := []web.Route{
+		{Method: http.MethodGet, Path: "/orders", Handler: ok},
+		{Method: http.MethodPost, Path: "/orders", Handler: ok},
+		{Method: http.MethodGet, Path: "/orders/:id", Handler: ok},
+	}
+	if err := web.Module(web.WithRouteTable(routes...)).Configure(c); err != nil {
+		t.Fatalf("web Configure() error = %v", err)
+	}
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := web.Engine(c)
+
+	w := get(engine, "/actuator/mappings")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /actuator/mappings = %d, want %d", w.Code, http.StatusOK)
+	}
+	var body struct {
+		Routes []struct {
+			Method string `json:"method"`
+			Path   string `json:"path"`
+		} `json:"routes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	listed := map[string]bool{}
+	for _, r := range body.Routes {
+		listed[r.Method+" "+r.Path] = true
+	}
+
+	for _, r := range routes {
+		if !listed[r.Method+" "+r.Path] {
+			t.Errorf("mappings missing %s %s", r.Method, r.Path)
+		}
+		path := strings.Replace(r.Path, ":id", "42", 1)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(r.Method, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s %s = %d, want %d", r.Method, path, rec.Code, http.StatusOK)
+		}
+	}
+	if !listed["GET /actuator/health"] {
+		t.Error("mappings missing actuator routes")
+	}
+}
+
+// switchSource is a config source whose Load can be made to fail.
+type switchSource struct {
+	mu   sync.Mutex
+	fail bool
+}
+
+func (s *switchSource) Name() string { return "switch" }
+
+func (s *switchSource) Load(context.Context) (map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return nil, errors.New("backend unavailable")
+	}
+	return map[string]any{"app": map[string]any{"name": "orders", "version": "1.0.0"}}, nil
+}
+
+func (s *switchSource) Watch(context.Context, chan<- config.Event) error { return nil }
+
+func TestModule_ReadinessReflectsConfigReload(t *testing.T) {
+	src := &switchSource{}
+	var cfg config.Root
+	mgr, err := config.NewManager(&cfg, config.Options{RefreshInterval: 10 * time.Millisecond}, src)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer mgr.Close()
+
+	c, engine := newTestContainer(config.Root{Actuator: config.ActuatorConfig{BasePath: "/actuator"}})
+	core.Put(c, mgr)
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	if w := get(engine, "/actuator/health/readiness"); w.Code != http.StatusOK {
+		t.Fatalf("GET readiness = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	src.mu.Lock()
+	src.fail = true
+	src.mu.Unlock()
+
+	deadline := time.Now().Add(2 * time.Second)
+	var w *httptest.ResponseRecorder
+	for time.Now().Before(deadline) {
+		if w = get(engine, "/actuator/health/readiness"); w.Code == http.StatusServiceUnavailable {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET readiness after failed reload = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	var body struct {
+		Status string  `json:"status"`
+		Checks []Check `json:"checks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	down := map[string]bool{}
+	for _, check := range body.Checks {
+		down[check.Name] = check.Status == StatusDown
+	}
+	if body.Status != StatusDown || !down["config"] || down["shutdown"] {
+		t.Errorf("readiness body = %s", w.Body.String())
+	}
+	if cfg.App.Name != "orders" {
+		t.Errorf("App.Name = %q, want old config still served", cfg.App.Name)
+	}
+}
+
+func TestModule_ConfigReloadPause(t *testing.T) {
+	var cfg config.Root
+	mgr, err := config.NewManager(&cfg, config.Options{}, &switchSource{})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer mgr.Close()
+
+	c, engine := newTestContainer(config.Root{Actuator: config.ActuatorConfig{BasePath: "/actuator"}})
+	core.Put(c, mgr)
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	post := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+	if w := post("/actuator/config/reload/pause"); w.Code != http.StatusOK || w.Body.String() != `{"paused":true}` {
+		t.Fatalf("POST pause = %d %s", w.Code, w.Body.String())
+	}
+	if !mgr.ReloadPaused() {
+		t.Error("ReloadPaused() = false after POST pause")
+	}
+	if w := get(engine, "/actuator/config/reload"); w.Body.String() != `{"paused":true}` {
+		t.Errorf("GET reload status = %s", w.Body.String())
+	}
+	if w := post("/actuator/config/reload/resume"); w.Code != http.StatusOK || w.Body.String() != `{"paused":false}` {
+		t.Fatalf("POST resume = %d %s", w.Code, w.Body.String())
+	}
+	if mgr.ReloadPaused() {
+		t.Error("ReloadPaused() = true after POST resume")
+	}
+}
+
+func TestModule_ReadinessWithoutIndicators(t *testing.T) {
+	c, engine := newTestContainer(config.Root{})
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if w := get(engine, "/health/readiness"); w.Code != http.StatusOK {
+		t.Errorf("GET /health/readiness = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestModule_ReadinessAggregatesSignals(t *testing.T) {
+	c, engine := newTestContainer(config.Root{})
+	m := Module(
+		WithReadiness(func() Check { return Check{Name: "database", Status: StatusUp} }),
+		WithReadiness(func() Check {
+			return Check{Name: "queue", Status: StatusDown, Details: map[string]any{"error": "connection refused"}}
+		}),
+	)
+	if err := m.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	w := get(engine, "/health/readiness")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /health/readiness = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	var body struct {
+		Status string  `json:"status"`
+		Checks []Check `json:"checks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	got := map[string]Check{}
+	for _, check := range body.Checks {
+		got[check.Name] = check
+	}
+	if body.Status != StatusDown || len(got) != 3 {
+		t.Fatalf("readiness body = %s", w.Body.String())
+	}
+	if got["shutdown"].Status != StatusUp || got["database"].Status != StatusUp {
+		t.Errorf("readiness body = %s, want shutdown and database UP", w.Body.String())
+	}
+	if q := got["queue"]; q.Status != StatusDown || q.Details["error"] != "connection refused" {
+		t.Errorf("queue check = %+v, want DOWN with error detail", q)
+	}
+}
+
+func TestModule_ReadinessDownWhileDraining(t *testing.T) {
+	c, engine := newTestContainer(config.Root{})
+	m := Module()
+	if err := m.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := m.(core.PreStopper).PreStop(context.Background(), c); err != nil {
+		t.Fatalf("PreStop() error = %v", err)
+	}
+	if w := get(engine, "/health/readiness"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /health/readiness while draining = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestModule_ReadinessWaitsForWebListener(t *testing.T) {
+	c, engine := newTestContainer(config.Root{})
+	var bound atomic.Bool
+	core.Put(c, web.ListeningFunc(func() (net.Addr, bool) {
+		if !bound.Load() {
+			return nil, false
+		}
+		return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}, true
+	}))
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	if w := get(engine, "/health/readiness"); w.Code != http.StatusServiceUnavailable ||
+		!strings.Contains(w.Body.String(), `"listening":false`) {
+		t.Errorf("GET /health/readiness before listening = %d %s, want %d with web DOWN", w.Code, w.Body.String(), http.StatusServiceUnavailable)
+	}
+	bound.Store(true)
+	if w := get(engine, "/health/readiness"); w.Code != http.StatusOK ||
+		!strings.Contains(w.Body.String(), `"addr":"127.0.0.1:8080"`) {
+		t.Errorf("GET /health/readiness once listening = %d %s, want %d with the address", w.Code, w.Body.String(), http.StatusOK)
+	}
+}
+
+// duplicateCollector emits the same metric twice, which fails a Gather of
+// whatever registry it's on.
+type duplicateCollector struct{}
+
+func (duplicateCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (duplicateCollector) Collect(ch chan<- prometheus.Metric) {
+	desc := prometheus.NewDesc("host_duplicate_total", "Collected twice.", nil, nil)
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, 1)
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, 1)
+}
+
+func TestModule_MetricsOwnRegistry(t *testing.T) {
+	// The host app breaks the default registry.
+	prometheus.MustRegister(duplicateCollector{})
+	defer prometheus.Unregister(duplicateCollector{})
+
+	root := config.Root{Observability: config.ObservabilityConfig{Metrics: config.MetricsConfig{Enabled: true}}}
+
+	t.Run("own registry", func(t *testing.T) {
+		c, engine := newTestContainer(root)
+		if err := Module().Configure(c); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+
+		// Registering the same metric twice is reported, not a panic.
+		reg := core.Get[*prometheus.Registry](c)
+		counter := func() prometheus.Counter {
+			return prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_total", Help: "Orders."})
+		}
+		if err := reg.Register(counter()); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		var already prometheus.AlreadyRegisteredError
+		if err := reg.Register(counter()); !errors.As(err, &already) {
+			t.Errorf("second Register() error = %v, want AlreadyRegisteredError", err)
+		}
+
+		w := get(engine, "/metrics")
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET /metrics = %d, want %d", w.Code, http.StatusOK)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "orders_total") || !strings.Contains(body, "go_goroutines") {
+			t.Errorf("metrics body missing registered metrics")
+		}
+		if strings.Contains(body, "host_duplicate_total") {
+			t.Errorf("metrics body includes default registry metrics")
+		}
+	})
+
+	t.Run("default registry", func(t *testing.T) {
+		c, engine := newTestContainer(root)
+		if err := Module(WithDefaultRegistry()).Configure(c); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		if w := get(engine, "/metrics"); w.Code != http.StatusInternalServerError {
+			t.Errorf("GET /metrics over the broken default registry = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+func TestModule_MetricsSharedRegistry(t *testing.T) {
+	root := config.Root{Observability: config.ObservabilityConfig{Metrics: config.MetricsConfig{Enabled: true}}}
+	c, engine := newTestContainer(root)
+
+	// Two modules declare the same counter, one before the actuator is
+	// configured and one after.
+	opts := prometheus.CounterOpts{Name: "orders_created_total", Help: "Orders created."}
+	metrics.Counter(c, opts).Inc()
+	if err := Module().Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	metrics.Counter(c, opts).Inc()
+
+	body := get(engine, "/metrics").Body.String()
+	if n := strings.Count(body, "# TYPE orders_created_total counter"); n != 1 {
+		t.Errorf("orders_created_total exported %d times, want once", n)
+	}
+	if !strings.Contains(body, "orders_created_total 2") {
+		t.Errorf("metrics body missing orders_created_total 2:\n%s", body)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/skekre98/genever/config"
 	"github.com/skekre98/genever/config/source"
 	"github.com/skekre98/genever/core"
+	"github.com/skekre98/genever/flags"
 	"github.com/skekre98/genever/logging"
 	"github.com/skekre98/genever/web"
 )
@@ -20,32 +22,20 @@ func main() {
 	//    File (base + profile) -> Environment vars -> CLI flags
 	var cfg config.Root
 
-	profile := os.Getenv("APP_PROFILE")
-	configPath := findConfigPath()
-
-	sources := []config.ConfigSource{
-		&source.FileSource{
-			BasePath: configPath,
-			Profile:  profile,
-		},
-		&source.EnvSource{},
-		&source.CLISource{},
-	}
-
-	_, err := config.NewManager(&cfg, config.Options{AutoReload: false}, sources...)
+	// Source stack comes from GENEVER_SOURCES (default file,env,cli)
+	sources, err := source.SourcesFromEnv()
 	if err != nil {
 		panic(err)
 	}
-
-	// Apply defaults
-	if cfg.Actuator.BasePath == "" {
-		cfg.Actuator.BasePath = "/actuator"
-	}
-	if cfg.Observability.Metrics.Path == "" {
-		cfg.Observability.Metrics.Path = "/actuator/metrics"
+	for _, src := range sources {
+		if fs, ok := src.(*source.FileSource); ok {
+			fs.BasePath = findConfigPath()
+		}
 	}
-	if cfg.Server.Addr == "" {
-		cfg.Server.Addr = ":8080"
+
+	mgr, err := config.NewManager(&cfg, config.Options{AutoReload: false}, sources...)
+	if err != nil {
+		panic(err)
 	}
 
 	// 2) logging
@@ -53,27 +43,36 @@ func main() {
 		slog.String("app", cfg.App.Name),
 		slog.String("version", cfg.App.Version),
 	)
+	if defaulted := mgr.Defaulted(); len(defaulted) > 0 {
+		logger.Info("using defaults", "fields", strings.Join(defaulted, ", "))
+	}
 
-	// 3) compose the app
-	app := core.NewApp(
+	// 3) compose the app, seeding shared objects into the container so
+	//    they're available to modules from Configure onwards
+	app := core.New(
 		logger,
-		// web server with some example routes
-		web.Module(
-			web.WithRoutes(func(r web.Router) {
-				r.GET("/hello", func(c *gin.Context) {
-					c.JSON(200, gin.H{"message": "world"})
-				})
-			}),
+		core.WithValue(core.TypeKey[config.Root]{}, cfg),
+		core.WithValue(core.TypeKey[*config.Manager]{}, mgr),
+		core.WithValue(core.TypeKey[*slog.Logger]{}, logger),
+		core.WithModules(
+			// web server with some example routes
+			web.Module(
+				web.WithRoutes(func(r web.Router) {
+					r.GET("/hello", func(c *gin.Context) {
+						c.JSON(200, gin.H{"message": "world"})
+					})
+				}),
+			),
+			// feature flags, served at /actuator/features
+			flags.Module(),
+			// actuator endpoints
+			actuator.Module(),
 		),
-		// actuator endpoints
-		actuator.Module(),
 	)
+	// stop config watchers once the modules have stopped
+	core.OnShutdown(app.Container, mgr)
 
-	// 4) seed shared objects into the container
-	app.Container.Set(core.TypeKey[config.Root]{}, cfg)
-	app.Container.Set(core.TypeKey[*slog.Logger]{}, logger)
-
-	// 5) run
+	// 4) run
 	if err := app.Run(context.Background()); err != nil {
 		logger.Error("app error", "error", err)
 		os.Exit(1)
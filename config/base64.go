@@ -0,0 +1,39 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeBase64 returns source with the values of fields tagged
+// `base64:"true"` decoded from standard base64 (padded or not), for
+// binaries such as TLS keys kept in env vars without their newlines. A
+// []byte field gets the decoded bytes, a string field their text.
+//
+// source itself is left encoded (see rewriteTagged); changed is false, and
+// out is source, if there was nothing to decode.
+func decodeBase64(source map[string]any, t reflect.Type, prefix string) (out map[string]any, changed bool, err error) {
+	return rewriteTagged(source, t, prefix, "base64", func(f reflect.StructField, path, s string) (any, bool, error) {
+		if f.Tag.Get("base64") != "true" {
+			return nil, false, nil
+		}
+		b, err := decodeBase64String(s)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: invalid base64: %w", path, err)
+		}
+		if indirectType(f.Type).Kind() == reflect.String {
+			return string(b), true, nil
+		}
+		return b, true, nil
+	})
+}
+
+func decodeBase64String(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "=") {
+		return base64.StdEncoding.DecodeString(s)
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
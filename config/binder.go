@@ -1,10 +1,19 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v3"
 )
 
 // Binder decodes map[string]any data into Go structs and validates the result.
@@ -16,13 +25,23 @@ import (
 // The Binder supports:
 //   - Automatic type conversion (string to int, string to duration, etc.)
 //   - Nested struct decoding
+//   - Embedded structs, bound under their type name or, when tagged
+//     `config:",squash"`, as if their fields were declared on the outer struct
 //   - Slice and map handling
 //   - Rich validation rules via struct tags
 //   - Custom decode hooks for complex types
+//   - Base64-encoded values for fields tagged `base64:"true"` (string or
+//     []byte), decoded before validation
+//   - Timestamps in time.Time fields: RFC 3339, or the layout given by a
+//     `timeformat:"2006-01-02"` tag
 //
 // Struct fields should use `config` tags for field mapping and `validate`
 // tags for validation rules.
 //
+// The validator caches the parsed validate tags of each type it sees, so
+// reuse a Binder for repeated binds, as the Manager does on every Reload.
+// A Binder is safe for concurrent use.
+//
 // Example struct:
 //
 //	type ServerConfig struct {
@@ -31,7 +50,75 @@ import (
 //	    Timeout time.Duration `config:"timeout" validate:"required"`
 //	}
 type Binder struct {
-	validator *validator.Validate
+	validator    *validator.Validate
+	weak         bool
+	strict       bool
+	durationUnit time.Duration
+	decodeHook   mapstructure.DecodeHookFunc
+}
+
+// BinderOption configures a Binder created with NewBinderWith.
+type BinderOption func(*Binder)
+
+// WithStrictTypes disables weak typing, so values must already have a type
+// compatible with the field they bind to: a bool bound to an int field, or
+// a string bound to a bool field, is a decode error. Durations and slices
+// are still parsed from strings by the decode hooks.
+//
+// Use it when sources produce typed values (YAML, JSON) and mismatches
+// should surface rather than be silently coerced.
+func WithStrictTypes() BinderOption {
+	return func(b *Binder) { b.weak = false }
+}
+
+// WithDurationUnit sets the unit of bare numbers bound to Duration and
+// time.Duration fields, e.g. time.Millisecond for `timeout: 250` to mean
+// 250ms. The default is DefaultDurationUnit.
+func WithDurationUnit(unit time.Duration) BinderOption {
+	return func(b *Binder) { b.durationUnit = unit }
+}
+
+// WithStrict makes keys in the source that no field binds to a decode
+// error, an *UnknownKeysError, instead of ignoring them, so typos in config
+// files surface. Keys inside map-typed fields are never unknown.
+func WithStrict() BinderOption {
+	return func(b *Binder) { b.strict = true }
+}
+
+// WithValidation registers fn as the rule for a validate tag, e.g.
+// "semver" for fields tagged `validate:"semver"`. It panics if tag is
+// empty or names a rule the validator reserves; see RegisterValidation to
+// handle that as an error.
+func WithValidation(tag string, fn validator.Func) BinderOption {
+	return func(b *Binder) {
+		if err := b.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// UnknownKeysError lists source keys that no config field binds to, as
+// dotted paths (e.g. "server.prot"). See WithStrict.
+type UnknownKeysError struct {
+	// Keys are the unknown keys, sorted. A map under an unknown key is
+	// listed by its leaves ("serevr.addr", not "serevr").
+	Keys []string
+
+	// Suggestions maps unknown keys that look like typos to the closest
+	// key of the config struct, e.g. "serevr.addr" to "server.addr".
+	Suggestions map[string]string
+}
+
+// Error implements the error interface.
+func (e *UnknownKeysError) Error() string {
+	keys := make([]string, len(e.Keys))
+	for i, k := range e.Keys {
+		keys[i] = k
+		if s, ok := e.Suggestions[k]; ok {
+			keys[i] += " (did you mean " + s + "?)"
+		}
+	}
+	return "unknown keys: " + strings.Join(keys, ", ")
 }
 
 // BindError represents an error that occurred during the bind or validate stage.
@@ -45,13 +132,62 @@ type BindError struct {
 
 	// Err is the underlying error from mapstructure or validator
 	Err error
+
+	// target is the type bound to, for naming fields in Failures.
+	target reflect.Type
 }
 
-// Error implements the error interface.
+// Error implements the error interface. A validate-stage error lists its
+// Failures.
 func (e *BindError) Error() string {
+	if failures := e.Failures(); len(failures) > 0 {
+		return fmt.Sprintf("config %s error: %s", e.Stage, strings.Join(failures, "; "))
+	}
 	return fmt.Sprintf("config %s error: %v", e.Stage, e.Err)
 }
 
+// Failures describes each rule that failed when Err is
+// validator.ValidationErrors, naming fields by config path rather than Go
+// field name:
+//
+//	field 'server.port' failed rule 'max': got 99999
+//
+// Values of secret fields (see Redact) are shown as Redacted. It returns
+// nil for other errors.
+func (e *BindError) Failures() []string {
+	var verrs validator.ValidationErrors
+	if !errors.As(e.Err, &verrs) {
+		return nil
+	}
+	out := make([]string, len(verrs))
+	for i, fe := range verrs {
+		out[i] = e.failure(fe)
+	}
+	return out
+}
+
+func (e *BindError) failure(fe validator.FieldError) string {
+	name, secret := fe.Namespace(), false
+	if _, rest, ok := strings.Cut(name, "."); ok {
+		name = rest // drop the type name
+	}
+	if e.target != nil {
+		if path, f, ok := namespaceField(e.target, fe.StructNamespace()); ok && len(path) > 0 {
+			name = strings.Join(path, ".")
+			secret = secretKey(path[len(path)-1]) || f.Tag.Get("secret") == "true"
+		}
+	}
+
+	var got any = Redacted
+	if !secret {
+		got = fe.Value()
+		if s, ok := got.(string); ok {
+			got = strconv.Quote(s)
+		}
+	}
+	return fmt.Sprintf("field '%s' failed rule '%s': got %v", name, fe.Tag(), got)
+}
+
 // Unwrap returns the underlying error, enabling errors.Is and errors.As.
 func (e *BindError) Unwrap() error {
 	return e.Err
@@ -60,14 +196,52 @@ func (e *BindError) Unwrap() error {
 // NewBinder creates a new Binder with default decode hooks and validators.
 //
 // The default configuration includes:
-//   - String to time.Duration conversion ("5s" -> 5*time.Second)
+//   - String or number to Duration and time.Duration conversion
+//     ("5s" -> 5*time.Second, 30 -> 30*time.Second)
 //   - Comma-separated string to slice conversion ("a,b,c" -> []string{"a","b","c"})
+//   - String to net.IP ("127.0.0.1") and to url.URL or *url.URL
+//     ("https://x.example")
+//   - String to time.Time, as RFC 3339 ("2024-01-01T00:00:00Z") or with
+//     the layout of the field's `timeformat` tag
 //   - Weak type conversion (string "123" -> int 123)
+//   - Defaults from `default` struct tags
+//   - String map keys to numeric key types ({"10": ...} -> map[int]T)
 //   - Standard validation rules from go-playground/validator
 func NewBinder() *Binder {
-	return &Binder{
-		validator: validator.New(),
+	return NewBinderWith()
+}
+
+// NewBinderWith creates a Binder like NewBinder, customized by opts.
+func NewBinderWith(opts ...BinderOption) *Binder {
+	b := &Binder{
+		validator:    validator.New(),
+		weak:         true,
+		durationUnit: DefaultDurationUnit,
+	}
+	for _, o := range opts {
+		o(b)
 	}
+	b.decodeHook = decodeHooks(b.durationUnit)
+	return b
+}
+
+// RegisterValidation registers fn as the rule for a validate tag, so
+// fields can carry domain rules alongside the standard ones:
+//
+//	b := config.NewBinder()
+//	err := b.RegisterValidation("cron", func(fl validator.FieldLevel) bool {
+//	    _, err := cron.ParseStandard(fl.Field().String())
+//	    return err == nil
+//	})
+//
+// A rule may replace a standard one of the same name. Register rules
+// before the first Bind: unlike Bind, RegisterValidation isn't safe to
+// call concurrently.
+func (b *Binder) RegisterValidation(tag string, fn validator.Func) error {
+	if err := b.validator.RegisterValidation(tag, fn); err != nil {
+		return fmt.Errorf("config: register validation %q: %w", tag, err)
+	}
+	return nil
 }
 
 // Bind decodes the source map into the target struct and validates it.
@@ -78,7 +252,9 @@ func NewBinder() *Binder {
 // The binding process:
 //  1. Decode source map into target struct using field tags
 //  2. Apply type conversions (strings to durations, etc.)
-//  3. Validate all fields against their validation rules
+//  3. Fill fields absent from source from their `default` tags (see
+//     ApplyDefaults); a value in source, even a zero one, wins
+//  4. Validate all fields against their validation rules
 //
 // If either stage fails, a BindError is returned with the stage and underlying
 // error. The target struct may be partially populated if decode succeeds but
@@ -95,43 +271,237 @@ func NewBinder() *Binder {
 //	err := binder.Bind(source, &cfg)
 //
 // Returns a BindError if:
-//   - Decode fails: type mismatch, invalid format, unknown field
+//   - Decode fails: type mismatch, invalid format, invalid base64 in a
+//     `base64:"true"` field, an invalid timestamp, an unparsable default
+//     tag, or with WithStrict a key no field binds to
 //   - Validate fails: value violates validation rules
 func (b *Binder) Bind(source map[string]any, target any) error {
+	_, err := b.bind(source, target, nil)
+	return err
+}
+
+// bind is Bind, also returning the paths filled from default tags. If prev
+// is non-nil, fields absent from source keep their non-default, non-zero
+// values from it (see Options.PreserveUnset) before defaults are applied.
+func (b *Binder) bind(source map[string]any, target any, prev *previous) ([]string, error) {
+	source, _, err := decodeBase64(source, reflect.TypeOf(target), "")
+	if err != nil {
+		return nil, &BindError{
+			Stage: "decode",
+			Err:   err,
+		}
+	}
+	source, err = parseTimeFormats(source, reflect.TypeOf(target))
+	if err != nil {
+		return nil, &BindError{
+			Stage: "decode",
+			Err:   err,
+		}
+	}
+
 	if err := b.decode(source, target); err != nil {
-		return &BindError{
+		return nil, &BindError{
+			Stage: "decode",
+			Err:   err,
+		}
+	}
+
+	if prev != nil {
+		defaulted := make(map[string]bool, len(prev.defaulted))
+		for _, path := range prev.defaulted {
+			defaulted[path] = true
+		}
+		preserveUnset(reflect.ValueOf(target), reflect.ValueOf(prev.cfg), source, "", defaulted)
+	}
+
+	if source == nil {
+		source = map[string]any{}
+	}
+	defaulted, err := applySourceDefaults(target, source, b.durationUnit)
+	if err != nil {
+		return nil, &BindError{
 			Stage: "decode",
 			Err:   err,
 		}
 	}
 
 	if err := b.validate(target); err != nil {
+		return nil, &BindError{
+			Stage:  "validate",
+			Err:    err,
+			target: reflect.TypeOf(target),
+		}
+	}
+
+	return defaulted, nil
+}
+
+// BindBytes unmarshals raw YAML or JSON data and binds it into target.
+//
+// It's a shortcut for one-off binding (tests, sources that already hold raw
+// bytes) that avoids constructing a source and Manager. format is "yaml",
+// "yml" or "json".
+//
+// Example:
+//
+//	var cfg ServerConfig
+//	err := config.BindBytes([]byte("port: 8080\nhost: localhost"), "yaml", &cfg)
+//
+// Returns a BindError with Stage "decode" if the data can't be parsed or
+// decoded, or Stage "validate" if validation fails. An unsupported format
+// returns a plain error.
+func BindBytes(data []byte, format string, target any) error {
+	source := map[string]any{}
+	var err error
+	switch format {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &source)
+	case "json":
+		err = json.Unmarshal(data, &source)
+	default:
+		return fmt.Errorf("config: unsupported format %q", format)
+	}
+	if err != nil {
 		return &BindError{
-			Stage: "validate",
+			Stage: "decode",
 			Err:   err,
 		}
 	}
+	return defaultBinder().Bind(source, target)
+}
 
-	return nil
+// defaultBinder is shared by BindBytes calls, so they reuse its validator's
+// cache.
+var defaultBinder = sync.OnceValue(NewBinder)
+
+// decodeHooks returns the hook converting source values to field types,
+// with bare numbers for Duration fields in durationUnit; see NewBinder.
+// Each Binder builds it once. The IP hook runs before the slice hook,
+// which would otherwise split the string for net.IP, a []byte.
+func decodeHooks(durationUnit time.Duration) mapstructure.DecodeHookFuncType {
+	return composeHooks(
+		durationHookFunc(durationUnit),
+		mapstructure.StringToIPHookFunc(),
+		stringToURLHookFunc(),
+		stringToTimeHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		stringMapKeysHookFunc(),
+	)
 }
 
 func (b *Binder) decode(source map[string]any, target any) error {
+	var md *mapstructure.Metadata
+	if b.strict {
+		md = &mapstructure.Metadata{}
+	}
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		Result:           target,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			mapstructure.StringToTimeDurationHookFunc(),
-			mapstructure.StringToSliceHookFunc(","),
-		),
-		TagName: "config",
+		WeaklyTypedInput: b.weak,
+		DecodeHook:       b.decodeHook,
+		TagName:          "config",
+		Metadata:         md,
 	})
 	if err != nil {
 		return err
 	}
 
-	return decoder.Decode(source)
+	if err := decoder.Decode(source); err != nil {
+		return err
+	}
+	if md != nil && len(md.Unused) > 0 {
+		return unknownKeysError(source, md.Unused, reflect.TypeOf(target))
+	}
+	return nil
 }
 
 func (b *Binder) validate(target any) error {
 	return b.validator.Struct(target)
 }
+
+var (
+	typeHookType = reflect.TypeOf(mapstructure.DecodeHookFuncType(nil))
+	kindHookType = reflect.TypeOf(mapstructure.DecodeHookFuncKind(nil))
+)
+
+// composeHooks is mapstructure.ComposeDecodeHookFunc, except that hooks are
+// converted to their typed form once, here. mapstructure converts an
+// untyped hook by reflection on every value it decodes, which made up most
+// of the cost of a bind; it does so cheaply for a DecodeHookFuncType.
+func composeHooks(hooks ...mapstructure.DecodeHookFunc) mapstructure.DecodeHookFuncType {
+	typed := make([]mapstructure.DecodeHookFuncType, len(hooks))
+	for i, h := range hooks {
+		v := reflect.ValueOf(h)
+		switch {
+		case v.Type().ConvertibleTo(typeHookType):
+			typed[i] = v.Convert(typeHookType).Interface().(mapstructure.DecodeHookFuncType)
+		case v.Type().ConvertibleTo(kindHookType):
+			kindHook := v.Convert(kindHookType).Interface().(mapstructure.DecodeHookFuncKind)
+			typed[i] = func(from, to reflect.Type, data any) (any, error) {
+				return kindHook(from.Kind(), to.Kind(), data)
+			}
+		default:
+			panic(fmt.Sprintf("config: unsupported decode hook %T", h))
+		}
+	}
+
+	return func(from, to reflect.Type, data any) (any, error) {
+		var err error
+		for _, h := range typed {
+			if data, err = h(from, to, data); err != nil {
+				return nil, err
+			}
+			if data == nil {
+				return nil, nil
+			}
+			from = reflect.TypeOf(data)
+		}
+		return data, nil
+	}
+}
+
+var urlType = reflect.TypeOf(url.URL{})
+
+// stringToURLHookFunc parses strings bound to url.URL and *url.URL fields.
+func stringToURLHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || indirectType(to) != urlType {
+			return data, nil
+		}
+		u, err := url.Parse(data.(string))
+		if err != nil {
+			return nil, err
+		}
+		if to.Kind() == reflect.Ptr {
+			return u, nil
+		}
+		return *u, nil
+	}
+}
+
+// stringMapKeysHookFunc converts the string keys sources produce to the
+// numeric or bool key type of the target map, so `limits: {"10": "low"}`
+// binds to map[int]string even with strict types. Typed-string keys
+// (map[Tier]int) need no conversion.
+func stringMapKeysHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.Map || from.Key().Kind() != reflect.String {
+			return data, nil
+		}
+		if to.Kind() != reflect.Map || to.Key().Kind() == reflect.String {
+			return data, nil
+		}
+
+		in := reflect.ValueOf(data)
+		out := make(map[any]any, in.Len())
+		iter := in.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			parsed, ok := parseKind(key, to.Key())
+			if !ok {
+				return nil, fmt.Errorf("invalid map key %q for %s", key, to)
+			}
+			out[parsed] = iter.Value().Interface()
+		}
+		return out, nil
+	}
+}
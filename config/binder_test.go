@@ -2,13 +2,26 @@ package config_test
 
 import (
 	"errors"
+	"net"
+	"net/url"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-playground/validator/v10"
+
 	"github.com/skekre98/genever/config"
 )
 
+var semverPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+
+// isSemver is a custom validation rule for the tests.
+func isSemver(fl validator.FieldLevel) bool {
+	return semverPattern.MatchString(fl.Field().String())
+}
+
 func TestBinder_Bind_SimpleTypes(t *testing.T) {
 	type SimpleConfig struct {
 		Name    string `config:"name" validate:"required"`
@@ -472,6 +485,126 @@ func TestBinder_Bind_EmptySource(t *testing.T) {
 	}
 }
 
+func TestBinder_Bind_IPAndURL(t *testing.T) {
+	type Config struct {
+		BindIP      net.IP        `config:"bindIP"`
+		UpstreamURL *url.URL      `config:"upstreamURL"`
+		Callback    url.URL       `config:"callback"`
+		Timeout     time.Duration `config:"timeout"`
+		Hosts       []string      `config:"hosts"`
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var cfg Config
+		err := config.NewBinder().Bind(map[string]any{
+			"bindIP":      "127.0.0.1",
+			"upstreamURL": "https://x.example/api?v=2",
+			"callback":    "http://cb.example",
+			"timeout":     "5s",
+			"hosts":       "a,b",
+		}, &cfg)
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if !cfg.BindIP.Equal(net.IPv4(127, 0, 0, 1)) {
+			t.Errorf("BindIP = %v", cfg.BindIP)
+		}
+		if cfg.UpstreamURL == nil || cfg.UpstreamURL.String() != "https://x.example/api?v=2" {
+			t.Errorf("UpstreamURL = %v", cfg.UpstreamURL)
+		}
+		if cfg.Callback.Host != "cb.example" {
+			t.Errorf("Callback = %v", cfg.Callback)
+		}
+		if cfg.Timeout != 5*time.Second || !reflect.DeepEqual(cfg.Hosts, []string{"a", "b"}) {
+			t.Errorf("Timeout, Hosts = %v, %v; other hooks must still run", cfg.Timeout, cfg.Hosts)
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		source map[string]any
+	}{
+		{"invalid IP", map[string]any{"bindIP": "localhost"}},
+		{"invalid URL", map[string]any{"upstreamURL": "http://[::1"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			err := config.NewBinder().Bind(tt.source, &cfg)
+			var bindErr *config.BindError
+			if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+				t.Errorf("Bind() error = %v, want decode BindError", err)
+			}
+		})
+	}
+}
+
+func TestBinder_Bind_Time(t *testing.T) {
+	type Window struct {
+		Start time.Time  `config:"start"`
+		End   *time.Time `config:"end"`
+		Day   time.Time  `config:"day" timeformat:"DateOnly"`
+		At    time.Time  `config:"at" timeformat:"2006-01-02 15:04"`
+	}
+	type Config struct {
+		Window  Window        `config:"window"`
+		Timeout time.Duration `config:"timeout"`
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var cfg Config
+		err := config.NewBinder().Bind(map[string]any{
+			"window": map[string]any{
+				"start": "2024-01-01T00:00:00Z",
+				"end":   "2024-01-01T02:30:00.5+01:00",
+				"day":   "2024-03-15",
+				"at":    "2024-03-15 22:00",
+			},
+			"timeout": "5s",
+		}, &cfg)
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !cfg.Window.Start.Equal(want) {
+			t.Errorf("Start = %v, want %v", cfg.Window.Start, want)
+		}
+		if want := time.Date(2024, 1, 1, 1, 30, 0, 5e8, time.UTC); cfg.Window.End == nil || !cfg.Window.End.Equal(want) {
+			t.Errorf("End = %v, want %v", cfg.Window.End, want)
+		}
+		if want := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC); !cfg.Window.Day.Equal(want) {
+			t.Errorf("Day = %v, want %v", cfg.Window.Day, want)
+		}
+		if want := time.Date(2024, 3, 15, 22, 0, 0, 0, time.UTC); !cfg.Window.At.Equal(want) {
+			t.Errorf("At = %v, want %v", cfg.Window.At, want)
+		}
+		if cfg.Timeout != 5*time.Second {
+			t.Errorf("Timeout = %v, want 5s", cfg.Timeout)
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		window map[string]any
+		path   string
+	}{
+		{"not RFC 3339", map[string]any{"start": "01/01/2024"}, "window.start"},
+		{"wrong custom layout", map[string]any{"at": "2024-03-15T22:00:00Z"}, "window.at"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			err := config.NewBinder().Bind(map[string]any{"window": tt.window}, &cfg)
+			var bindErr *config.BindError
+			if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+				t.Fatalf("Bind() error = %v, want decode BindError", err)
+			}
+			if !strings.Contains(err.Error(), tt.path) {
+				t.Errorf("error %q doesn't name %s", err, tt.path)
+			}
+		})
+	}
+}
+
 func TestBinder_Bind_ExtraFields(t *testing.T) {
 	type Config struct {
 		Name string `config:"name"`
@@ -503,6 +636,167 @@ func TestBinder_Bind_ExtraFields(t *testing.T) {
 	}
 }
 
+func TestBinder_Bind_Strict(t *testing.T) {
+	type ServerConfig struct {
+		Addr string `config:"addr"`
+		Port int    `config:"port" default:"8080"`
+	}
+	type Config struct {
+		Name   string            `config:"name"`
+		Server ServerConfig      `config:"server"`
+		Labels map[string]string `config:"labels"`
+	}
+	source := map[string]any{
+		"name":   "orders",
+		"server": map[string]any{"addr": "0.0.0.0", "prot": 9090},
+		"labels": map[string]any{"team": "payments"},
+		"extra":  true,
+	}
+
+	var lenient Config
+	if err := config.NewBinder().Bind(source, &lenient); err != nil {
+		t.Fatalf("lenient Bind() error = %v", err)
+	}
+	if lenient.Server.Port != 8080 {
+		t.Errorf("lenient Port = %d, want the default 8080", lenient.Server.Port)
+	}
+
+	var strict Config
+	err := config.NewBinderWith(config.WithStrict()).Bind(source, &strict)
+	var bindErr *config.BindError
+	if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+		t.Fatalf("strict Bind() error = %v, want decode BindError", err)
+	}
+	var unknown *config.UnknownKeysError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("strict Bind() error = %v, want UnknownKeysError", err)
+	}
+	if want := []string{"extra", "server.prot"}; !reflect.DeepEqual(unknown.Keys, want) {
+		t.Errorf("unknown keys = %v, want %v", unknown.Keys, want)
+	}
+}
+
+func TestBinder_RegisterValidation(t *testing.T) {
+	type Config struct {
+		Version string `config:"version" validate:"required,semver"`
+	}
+
+	binders := map[string]func() *config.Binder{
+		"RegisterValidation": func() *config.Binder {
+			b := config.NewBinder()
+			if err := b.RegisterValidation("semver", isSemver); err != nil {
+				t.Fatalf("RegisterValidation() error = %v", err)
+			}
+			return b
+		},
+		"WithValidation": func() *config.Binder {
+			return config.NewBinderWith(config.WithValidation("semver", isSemver))
+		},
+	}
+	for name, newBinder := range binders {
+		t.Run(name, func(t *testing.T) {
+			b := newBinder()
+
+			var ok Config
+			if err := b.Bind(map[string]any{"version": "1.4.2"}, &ok); err != nil {
+				t.Fatalf("Bind() valid version error = %v", err)
+			}
+
+			var bad Config
+			err := b.Bind(map[string]any{"version": "latest"}, &bad)
+			var bindErr *config.BindError
+			if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+				t.Fatalf("Bind() invalid version error = %v, want validate BindError", err)
+			}
+			var fieldErrs validator.ValidationErrors
+			if !errors.As(err, &fieldErrs) || fieldErrs[0].Tag() != "semver" {
+				t.Errorf("Bind() error = %v, want a semver failure", err)
+			}
+		})
+	}
+
+	if err := config.NewBinder().RegisterValidation("", isSemver); err == nil {
+		t.Error("RegisterValidation() with empty tag: expected error")
+	}
+}
+
+func TestBindError_Failures(t *testing.T) {
+	type ServerConfig struct {
+		Port int `config:"port" validate:"min=1,max=65535"`
+	}
+	type Config struct {
+		Name     string       `config:"appName" validate:"required"`
+		Server   ServerConfig `config:"server"`
+		Tags     []string     `config:"tags" validate:"dive,max=3"`
+		Password string       `config:"password" validate:"min=8"`
+	}
+	source := map[string]any{
+		"server":   map[string]any{"port": 99999},
+		"tags":     []any{"ok", "toolong"},
+		"password": "hunter2",
+	}
+
+	var cfg Config
+	err := config.NewBinder().Bind(source, &cfg)
+	var bindErr *config.BindError
+	if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+		t.Fatalf("Bind() error = %v, want validate BindError", err)
+	}
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 4 {
+		t.Fatalf("errors.As(ValidationErrors) = %v, want 4 failures", fieldErrs)
+	}
+
+	want := []string{
+		`field 'appName' failed rule 'required': got ""`,
+		`field 'server.port' failed rule 'max': got 99999`,
+		`field 'tags[1]' failed rule 'max': got "toolong"`,
+		`field 'password' failed rule 'min': got ******`,
+	}
+	if got := bindErr.Failures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Failures() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if msg := err.Error(); !strings.Contains(msg, want[0]+"; "+want[1]) || strings.Contains(msg, "hunter2") {
+		t.Errorf("Error() = %q, want the failures joined without secrets", msg)
+	}
+
+	decodeErr := &config.BindError{Stage: "decode", Err: errors.New("bad")}
+	if got := decodeErr.Failures(); got != nil {
+		t.Errorf("Failures() of a decode error = %v, want nil", got)
+	}
+}
+
+func TestBinder_Bind_StrictSuggestions(t *testing.T) {
+	type ServerConfig struct {
+		Addr string `config:"addr"`
+		Port int    `config:"port"`
+	}
+	type Config struct {
+		Name   string       `config:"name"`
+		Server ServerConfig `config:"server"`
+	}
+	source := map[string]any{
+		"serevr":    map[string]any{"addr": "0.0.0.0"},
+		"telemetry": true,
+	}
+
+	var cfg Config
+	err := config.NewBinderWith(config.WithStrict()).Bind(source, &cfg)
+	var unknown *config.UnknownKeysError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Bind() error = %v, want UnknownKeysError", err)
+	}
+	if want := []string{"serevr.addr", "telemetry"}; !reflect.DeepEqual(unknown.Keys, want) {
+		t.Errorf("unknown keys = %v, want %v", unknown.Keys, want)
+	}
+	if want := map[string]string{"serevr.addr": "server.addr"}; !reflect.DeepEqual(unknown.Suggestions, want) {
+		t.Errorf("suggestions = %v, want %v", unknown.Suggestions, want)
+	}
+	if want := "unknown keys: serevr.addr (did you mean server.addr?), telemetry"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to contain %q", err, want)
+	}
+}
+
 func TestBindError_Error(t *testing.T) {
 	err := &config.BindError{
 		Stage: "decode",
@@ -526,3 +820,374 @@ func TestBindError_Unwrap(t *testing.T) {
 		t.Errorf("Unwrap() = %v, want %v", err.Unwrap(), innerErr)
 	}
 }
+
+func TestBinder_Bind_ServerMode(t *testing.T) {
+	binder := config.NewBinder()
+	source := func(mode string) map[string]any {
+		return map[string]any{
+			"app":    map[string]any{"name": "app", "version": "1.0.0"},
+			"server": map[string]any{"addr": ":8080", "mode": mode},
+		}
+	}
+
+	var ok config.Root
+	if err := binder.Bind(source("debug"), &ok); err != nil {
+		t.Fatalf("Bind() with mode debug error = %v", err)
+	}
+	if ok.Server.Mode != "debug" {
+		t.Errorf("Server.Mode = %q, want %q", ok.Server.Mode, "debug")
+	}
+
+	var bad config.Root
+	err := binder.Bind(source("production"), &bad)
+	var bindErr *config.BindError
+	if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+		t.Errorf("Bind() with mode production error = %v, want validate BindError", err)
+	}
+}
+
+func TestBindBytes(t *testing.T) {
+	type DatabaseConfig struct {
+		Host    string        `config:"host" validate:"required"`
+		Port    int           `config:"port" validate:"required,min=1,max=65535"`
+		Timeout time.Duration `config:"timeout"`
+	}
+	type AppConfig struct {
+		Name     string         `config:"name" validate:"required"`
+		Database DatabaseConfig `config:"database"`
+	}
+
+	t.Run("yaml nested struct", func(t *testing.T) {
+		data := []byte(`
+name: orders
+database:
+  host: db.local
+  port: 5432
+  timeout: 3s
+`)
+		var cfg AppConfig
+		if err := config.BindBytes(data, "yaml", &cfg); err != nil {
+			t.Fatalf("BindBytes() error = %v", err)
+		}
+		want := AppConfig{
+			Name:     "orders",
+			Database: DatabaseConfig{Host: "db.local", Port: 5432, Timeout: 3 * time.Second},
+		}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("BindBytes() got = %+v, want %+v", cfg, want)
+		}
+	})
+
+	t.Run("json nested struct", func(t *testing.T) {
+		data := []byte(`{"name": "orders", "database": {"host": "db.local", "port": 5432}}`)
+		var cfg AppConfig
+		if err := config.BindBytes(data, "json", &cfg); err != nil {
+			t.Fatalf("BindBytes() error = %v", err)
+		}
+		if cfg.Database.Port != 5432 {
+			t.Errorf("Database.Port = %d, want 5432", cfg.Database.Port)
+		}
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		data := []byte("name: orders\ndatabase:\n  host: db.local\n  port: 99999\n")
+		var cfg AppConfig
+		err := config.BindBytes(data, "yaml", &cfg)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+			t.Errorf("BindBytes() error = %v, want validate BindError", err)
+		}
+	})
+
+	t.Run("malformed data", func(t *testing.T) {
+		var cfg AppConfig
+		err := config.BindBytes([]byte(`{"name": `), "json", &cfg)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+			t.Errorf("BindBytes() error = %v, want decode BindError", err)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		var cfg AppConfig
+		if err := config.BindBytes([]byte(""), "toml", &cfg); err == nil {
+			t.Error("BindBytes() with unsupported format: expected error")
+		}
+	})
+}
+
+func TestBinder_Bind_StrictTypes(t *testing.T) {
+	type AppConfig struct {
+		Port    int           `config:"port"`
+		Debug   bool          `config:"debug"`
+		Timeout time.Duration `config:"timeout"`
+		Tags    []string      `config:"tags"`
+	}
+
+	t.Run("type mismatch is a decode error", func(t *testing.T) {
+		source := map[string]any{"port": true}
+
+		var lenient AppConfig
+		if err := config.NewBinder().Bind(source, &lenient); err != nil {
+			t.Fatalf("weakly typed Bind() error = %v, want silent coercion", err)
+		}
+
+		var strict AppConfig
+		err := config.NewBinderWith(config.WithStrictTypes()).Bind(source, &strict)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+			t.Errorf("strict Bind() error = %v, want decode BindError", err)
+		}
+	})
+
+	t.Run("typed values bind", func(t *testing.T) {
+		source := map[string]any{
+			"port":    8080,
+			"debug":   true,
+			"timeout": "5s",
+			"tags":    "a,b",
+		}
+		var cfg AppConfig
+		if err := config.NewBinderWith(config.WithStrictTypes()).Bind(source, &cfg); err != nil {
+			t.Fatalf("strict Bind() error = %v", err)
+		}
+		want := AppConfig{Port: 8080, Debug: true, Timeout: 5 * time.Second, Tags: []string{"a", "b"}}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("strict Bind() got = %+v, want %+v", cfg, want)
+		}
+	})
+
+	t.Run("string for bool is rejected", func(t *testing.T) {
+		var cfg AppConfig
+		err := config.NewBinderWith(config.WithStrictTypes()).Bind(map[string]any{"debug": "true"}, &cfg)
+		if err == nil {
+			t.Error("strict Bind() with string for bool: expected error")
+		}
+	})
+}
+
+type tier string
+
+func TestBinder_Bind_MapKeys(t *testing.T) {
+	type AppConfig struct {
+		Limits map[int]string  `config:"limits"`
+		Ports  map[uint16]bool `config:"ports"`
+		Tiers  map[tier]int    `config:"tiers"`
+	}
+
+	source := map[string]any{
+		"limits": map[string]any{"10": "low", "100": "high"},
+		"ports":  map[string]any{"8080": true},
+		"tiers":  map[string]any{"gold": 3},
+	}
+	want := AppConfig{
+		Limits: map[int]string{10: "low", 100: "high"},
+		Ports:  map[uint16]bool{8080: true},
+		Tiers:  map[tier]int{"gold": 3},
+	}
+
+	binders := map[string]*config.Binder{
+		"weak":   config.NewBinder(),
+		"strict": config.NewBinderWith(config.WithStrictTypes()),
+	}
+	for name, b := range binders {
+		t.Run(name, func(t *testing.T) {
+			var cfg AppConfig
+			if err := b.Bind(source, &cfg); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg, want) {
+				t.Errorf("Bind() got = %+v, want %+v", cfg, want)
+			}
+		})
+	}
+
+	t.Run("invalid key", func(t *testing.T) {
+		var cfg AppConfig
+		err := config.NewBinder().Bind(map[string]any{"limits": map[string]any{"ten": "low"}}, &cfg)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+			t.Errorf("Bind() error = %v, want decode BindError", err)
+		}
+	})
+}
+
+func TestBinder_Bind_EmbeddedStructs(t *testing.T) {
+	type BaseConfig struct {
+		Name    string `config:"name" validate:"required"`
+		Timeout string `config:"timeout" default:"5s"`
+	}
+	type SquashedConfig struct {
+		BaseConfig `config:",squash"`
+		Port       int `config:"port"`
+	}
+	type NestedConfig struct {
+		BaseConfig
+		Port int `config:"port"`
+	}
+
+	t.Run("squash", func(t *testing.T) {
+		var cfg SquashedConfig
+		if err := config.NewBinder().Bind(map[string]any{"name": "orders", "port": 8080}, &cfg); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if cfg.Name != "orders" || cfg.Port != 8080 || cfg.Timeout != "5s" {
+			t.Errorf("Bind() got = %+v", cfg)
+		}
+		defaulted, err := config.ApplyDefaults(&SquashedConfig{})
+		if err != nil || !reflect.DeepEqual(defaulted, []string{"timeout"}) {
+			t.Errorf("ApplyDefaults() = %v, %v, want [timeout]", defaulted, err)
+		}
+	})
+
+	t.Run("squash missing required", func(t *testing.T) {
+		var cfg SquashedConfig
+		err := config.NewBinder().Bind(map[string]any{"port": 8080}, &cfg)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+			t.Fatalf("Bind() error = %v, want validate BindError", err)
+		}
+		if got := config.MissingRequired(err, cfg); !reflect.DeepEqual(got, []string{"name"}) {
+			t.Errorf("MissingRequired() = %v, want [name]", got)
+		}
+	})
+
+	t.Run("without squash", func(t *testing.T) {
+		var cfg NestedConfig
+		source := map[string]any{"BaseConfig": map[string]any{"name": "orders"}, "port": 8080}
+		if err := config.NewBinder().Bind(source, &cfg); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if cfg.Name != "orders" || cfg.Port != 8080 {
+			t.Errorf("Bind() got = %+v", cfg)
+		}
+	})
+
+	t.Run("without squash missing required", func(t *testing.T) {
+		var cfg NestedConfig
+		err := config.NewBinder().Bind(map[string]any{"name": "orders"}, &cfg)
+		if got := config.MissingRequired(err, cfg); !reflect.DeepEqual(got, []string{"BaseConfig.name"}) {
+			t.Errorf("MissingRequired() = %v (err %v), want [BaseConfig.name]", got, err)
+		}
+	})
+}
+
+func TestBinder_Bind_Base64(t *testing.T) {
+	type TLSConfig struct {
+		Key  []byte `config:"key" base64:"true" validate:"required"`
+		Cert string `config:"cert" base64:"true"`
+		Name string `config:"name"`
+	}
+	type AppConfig struct {
+		TLS TLSConfig `config:"tls"`
+	}
+
+	t.Run("decodes", func(t *testing.T) {
+		tls := map[string]any{
+			"key":  "LS0tLS1CRUdJTgprZXkKLS0tLS1FTkQ=", // padded
+			"cert": "Y2VydA",                           // unpadded
+			"name": "Y2VydA",
+		}
+		source := map[string]any{"tls": tls}
+
+		var cfg AppConfig
+		if err := config.NewBinder().Bind(source, &cfg); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if string(cfg.TLS.Key) != "-----BEGIN\nkey\n-----END" {
+			t.Errorf("Key = %q", cfg.TLS.Key)
+		}
+		if cfg.TLS.Cert != "cert" {
+			t.Errorf("Cert = %q, want cert", cfg.TLS.Cert)
+		}
+		if cfg.TLS.Name != "Y2VydA" {
+			t.Errorf("Name = %q, untagged fields must not be decoded", cfg.TLS.Name)
+		}
+		if tls["key"] != "LS0tLS1CRUdJTgprZXkKLS0tLS1FTkQ=" {
+			t.Errorf("source map modified: key = %v", tls["key"])
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		var cfg AppConfig
+		err := config.NewBinder().Bind(map[string]any{"tls": map[string]any{"key": "not base64!"}}, &cfg)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+			t.Fatalf("Bind() error = %v, want decode BindError", err)
+		}
+		if !strings.Contains(err.Error(), "tls.key") {
+			t.Errorf("error %q doesn't name the field", err)
+		}
+	})
+}
+
+// BenchmarkBinder_Bind measures repeated binds of the same type, as on every
+// Reload: with one Binder, whose validator caches the parsed validate tags
+// of the type, and with a new Binder per bind, which parses them each time.
+func BenchmarkBinder_Bind(b *testing.B) {
+	type DBConfig struct {
+		URL      string        `config:"url" validate:"required,url"`
+		User     string        `config:"user" validate:"required"`
+		Password string        `config:"password" secret:"true"`
+		MaxConns int           `config:"maxConns" validate:"min=1,max=100" default:"10"`
+		Timeout  time.Duration `config:"timeout" default:"5s"`
+	}
+	type ServerConfig struct {
+		Addr         string        `config:"addr" validate:"required,hostname_port"`
+		ReadTimeout  time.Duration `config:"readTimeout" validate:"gt=0"`
+		WriteTimeout time.Duration `config:"writeTimeout" validate:"gt=0"`
+		Origins      []string      `config:"origins" validate:"dive,url"`
+	}
+	type AppConfig struct {
+		Name    string            `config:"name" validate:"required"`
+		Version string            `config:"version" validate:"required,semver"`
+		Server  ServerConfig      `config:"server"`
+		DB      DBConfig          `config:"db"`
+		Labels  map[string]string `config:"labels"`
+	}
+	source := map[string]any{
+		"name":    "orders",
+		"version": "1.2.3",
+		"server": map[string]any{
+			"addr": "0.0.0.0:8080", "readTimeout": "5s", "writeTimeout": "10s",
+			"origins": "https://a.example.com,https://b.example.com",
+		},
+		"db": map[string]any{
+			"url": "postgres://db:5432/orders", "user": "orders", "password": "s3cret", "maxConns": "20",
+		},
+		"labels": map[string]any{"team": "payments", "tier": "1"},
+	}
+
+	b.Run("baseline", func(b *testing.B) {
+		// Hooks composed by mapstructure and no per-type caches, as
+		// before they were added.
+		binder := config.NewBaselineBinder()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			config.ResetBindCaches()
+			var cfg AppConfig
+			if err := binder.Bind(source, &cfg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reused binder", func(b *testing.B) {
+		binder := config.NewBinder()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var cfg AppConfig
+			if err := binder.Bind(source, &cfg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("new binder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var cfg AppConfig
+			if err := config.NewBinder().Bind(source, &cfg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
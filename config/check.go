@@ -0,0 +1,136 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultCheckTimeout bounds each startup check when Options.CheckTimeout
+// is zero.
+const DefaultCheckTimeout = 5 * time.Second
+
+// CheckFunc verifies that the dependency a config value refers to is
+// usable, e.g. that a database address accepts connections. It should
+// return promptly when ctx is done.
+type CheckFunc func(ctx context.Context, value any) error
+
+// CheckError reports a startup check that failed.
+type CheckError struct {
+	// Path is the dotted config path of the checked field.
+	Path string
+
+	// Check is the name of the check, as used in the field's check tag.
+	Check string
+
+	Err error
+}
+
+// Error implements the error interface.
+func (e *CheckError) Error() string {
+	return fmt.Sprintf("config: check %s of %s failed: %v", e.Check, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CheckError) Unwrap() error {
+	return e.Err
+}
+
+// TCPCheck is a CheckFunc that dials the address in value: "host:port",
+// or a URL such as postgres://db:5432/orders (the port defaults to the
+// scheme's). Empty values pass, so optional fields can be checked.
+func TCPCheck(ctx context.Context, value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("tcp check needs a string, got %T", value)
+	}
+	if s == "" {
+		return nil
+	}
+	addr := s
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+		addr = u.Host
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), u.Scheme)
+		}
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// checkTarget is a field tagged with check names.
+type checkTarget struct {
+	path   string
+	value  any
+	checks []string
+}
+
+// runChecks runs the checks named by `check` tags in cfg, each bounded by
+// timeout, and returns the first failure. A tag naming a check missing
+// from checks is an error.
+func runChecks(ctx context.Context, cfg any, checks map[string]CheckFunc, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	var targets []checkTarget
+	collectChecks(reflect.ValueOf(cfg), "", &targets)
+
+	for _, target := range targets {
+		for _, name := range target.checks {
+			fn, ok := checks[name]
+			if !ok {
+				return &CheckError{Path: target.path, Check: name, Err: fmt.Errorf("no check registered as %q", name)}
+			}
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			err := fn(checkCtx, target.value)
+			cancel()
+			if err != nil {
+				return &CheckError{Path: target.path, Check: name, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+func collectChecks(v reflect.Value, prefix string, out *[]checkTarget) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			collectChecks(v.Field(i), prefix, out)
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		path := joinPath(prefix, key)
+		if tag := f.Tag.Get("check"); tag != "" {
+			*out = append(*out, checkTarget{path: path, value: v.Field(i).Interface(), checks: strings.Split(tag, ",")})
+			continue
+		}
+		collectChecks(v.Field(i), path, out)
+	}
+}
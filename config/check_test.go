@@ -0,0 +1,82 @@
+package config_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestNewManager_Checks(t *testing.T) {
+	type DBConfig struct {
+		Addr string `config:"addr" check:"tcp"`
+	}
+	type AppConfig struct {
+		DB DBConfig `config:"db"`
+	}
+
+	reachable, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer reachable.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	unreachable := closed.Addr().String()
+	closed.Close()
+
+	opts := config.Options{
+		Checks:       map[string]config.CheckFunc{"tcp": config.TCPCheck},
+		CheckTimeout: time.Second,
+	}
+
+	t.Run("reachable", func(t *testing.T) {
+		source := &mockSource{name: "file", data: map[string]any{
+			"db": map[string]any{"addr": reachable.Addr().String()},
+		}}
+		var cfg AppConfig
+		if _, err := config.NewManager(&cfg, opts, source); err != nil {
+			t.Errorf("NewManager() error = %v", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		source := &mockSource{name: "file", data: map[string]any{
+			"db": map[string]any{"addr": "tcp://" + unreachable},
+		}}
+		var cfg AppConfig
+		_, err := config.NewManager(&cfg, opts, source)
+		var checkErr *config.CheckError
+		if !errors.As(err, &checkErr) {
+			t.Fatalf("NewManager() error = %v, want *CheckError", err)
+		}
+		if checkErr.Path != "db.addr" || checkErr.Check != "tcp" {
+			t.Errorf("CheckError = %+v, want tcp check of db.addr", checkErr)
+		}
+	})
+
+	t.Run("not opted in", func(t *testing.T) {
+		source := &mockSource{name: "file", data: map[string]any{
+			"db": map[string]any{"addr": unreachable},
+		}}
+		var cfg AppConfig
+		if _, err := config.NewManager(&cfg, config.Options{}, source); err != nil {
+			t.Errorf("NewManager() without Checks error = %v", err)
+		}
+	})
+
+	t.Run("unknown check", func(t *testing.T) {
+		source := &mockSource{name: "file", data: map[string]any{}}
+		var cfg AppConfig
+		_, err := config.NewManager(&cfg, config.Options{Checks: map[string]config.CheckFunc{"http": config.TCPCheck}}, source)
+		var checkErr *config.CheckError
+		if !errors.As(err, &checkErr) || checkErr.Check != "tcp" {
+			t.Errorf("NewManager() error = %v, want unregistered check error", err)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// coerceTypes converts string values in m to the kind of the struct field
+// they bind to (bool, int, uint, float), using t as the schema.
+//
+// String-only sources such as environment variables and CLI flags rely on
+// the Binder's weak typing to reach typed fields. Coercing the merged map up
+// front means map consumers also see typed values ("true" -> true).
+//
+// Values that don't parse are left untouched so the Binder can report them.
+// m is modified in place.
+func coerceTypes(m map[string]any, t reflect.Type) {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for k, v := range m {
+		f, ok := lookupField(t, k)
+		if !ok {
+			continue
+		}
+		m[k] = coerceValue(v, f.Type)
+	}
+}
+
+func coerceValue(v any, t reflect.Type) any {
+	t = indirectType(t)
+	switch val := v.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			coerceTypes(val, t)
+		case reflect.Map:
+			for k, ev := range val {
+				val[k] = coerceValue(ev, t.Elem())
+			}
+		}
+		return val
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, ev := range val {
+				val[i] = coerceValue(ev, t.Elem())
+			}
+		}
+		return val
+	case string:
+		if parsed, ok := parseKind(val, t); ok {
+			return parsed
+		}
+	}
+	return v
+}
+
+// parseKind parses s as the basic kind of t. time.Duration is left as a
+// string so the duration decode hook can handle values like "5s".
+func parseKind(s string, t reflect.Type) (any, bool) {
+	if t == durationType {
+		return nil, false
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			return int(i), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			return uint(u), true
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(s, t.Bits()); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
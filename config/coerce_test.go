@@ -0,0 +1,74 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCoerceTypes(t *testing.T) {
+	type Server struct {
+		Port    int           `config:"port"`
+		Debug   bool          `config:"debug"`
+		Ratio   float64       `config:"ratio"`
+		Workers uint          `config:"workers"`
+		Timeout time.Duration `config:"timeout"`
+		Host    string        `config:"host"`
+	}
+	type Root struct {
+		Server   Server          `config:"server"`
+		Enabled  bool            `config:"enabled"`
+		Features map[string]bool `config:"features"`
+	}
+
+	m := map[string]any{
+		"enabled": "true",
+		"server": map[string]any{
+			"port":    "8080",
+			"DEBUG":   "false",
+			"ratio":   "0.5",
+			"workers": "4",
+			"timeout": "5s",
+			"host":    "localhost",
+		},
+		"features": map[string]any{
+			"beta": "true",
+		},
+		"unknown": "123",
+	}
+
+	coerceTypes(m, reflect.TypeOf(&Root{}))
+
+	want := map[string]any{
+		"enabled": true,
+		"server": map[string]any{
+			"port":    8080,
+			"DEBUG":   false,
+			"ratio":   0.5,
+			"workers": uint(4),
+			"timeout": "5s",
+			"host":    "localhost",
+		},
+		"features": map[string]any{
+			"beta": true,
+		},
+		"unknown": "123",
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("coerceTypes() = %#v, want %#v", m, want)
+	}
+}
+
+func TestCoerceTypes_InvalidValuesUntouched(t *testing.T) {
+	type Root struct {
+		Port    int  `config:"port"`
+		Enabled bool `config:"enabled"`
+	}
+
+	m := map[string]any{"port": "eighty", "enabled": "yes please"}
+	coerceTypes(m, reflect.TypeOf(Root{}))
+
+	if m["port"] != "eighty" || m["enabled"] != "yes please" {
+		t.Errorf("invalid values should be left as strings, got %#v", m)
+	}
+}
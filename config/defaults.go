@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ApplyDefaults fills zero-valued fields of cfg from their `default` tags.
+//
+// cfg must be a pointer to a struct. Nested structs (and non-nil pointers to
+// structs) are walked recursively. Tag values are parsed as the field's type:
+// strings, bools, integers, floats, time.Duration ("5s"), Duration and
+// slices of those (comma-separated).
+//
+// Example:
+//
+//	type ServerConfig struct {
+//	    Addr        string        `config:"addr" default:":8080" validate:"required"`
+//	    ReadTimeout time.Duration `config:"readTimeout" default:"5s"`
+//	}
+//
+// A field explicitly set to its zero value is indistinguishable from an
+// unset one here and also gets the default. The Binder, which applies
+// defaults after decoding and before validation (so a defaulted field
+// satisfies `required`), knows better: it only defaults fields whose key is
+// absent from the source, so `debug: false` overrides `default:"true"`.
+//
+// It returns the dotted config paths of the fields it filled (e.g.
+// "server.addr"), so startup can log which values came from defaults.
+//
+// Returns an error if cfg is not a pointer to a struct or a tag value can't
+// be parsed as its field's type.
+func ApplyDefaults(cfg any) ([]string, error) {
+	return applySourceDefaults(cfg, nil, DefaultDurationUnit)
+}
+
+// applySourceDefaults is ApplyDefaults for cfg decoded from source: if
+// source is non-nil, fields whose key it sets keep their decoded value,
+// even if it's zero. Bare numbers for Duration fields are in unit.
+func applySourceDefaults(cfg any, source map[string]any, unit time.Duration) ([]string, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: ApplyDefaults requires a non-nil pointer to a struct, got %T", cfg)
+	}
+	var applied []string
+	if err := applyDefaults(v.Elem(), "", source, unit, &applied); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// applyDefaults fills the zero fields of struct v from their default tags,
+// except those set by source if it's non-nil (see applySourceDefaults).
+func applyDefaults(v reflect.Value, prefix string, source map[string]any, unit time.Duration, applied *[]string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			if err := applyDefaults(v.Field(i), prefix, source, unit, applied); err != nil {
+				return err
+			}
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		path := joinPath(prefix, key)
+		fv := v.Field(i)
+
+		tag, hasDefault := f.Tag.Lookup("default")
+
+		var nested map[string]any
+		if source != nil {
+			val, _ := sourceValue(source, key)
+			if hasDefault && val != nil {
+				continue // set explicitly
+			}
+			if nested, _ = val.(map[string]any); nested == nil {
+				nested = map[string]any{} // nothing below is set
+			}
+		}
+
+		if hasDefault {
+			if !fv.IsZero() {
+				continue
+			}
+			parsed, err := parseDefault(tag, f.Type, unit)
+			if err != nil {
+				return fmt.Errorf("config: default for %s.%s: %w", t.Name(), f.Name, err)
+			}
+			fv.Set(parsed)
+			*applied = append(*applied, path)
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct:
+			if err := applyDefaults(fv, path, nested, unit, applied); err != nil {
+				return err
+			}
+		case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+			if err := applyDefaults(fv.Elem(), path, nested, unit, applied); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseDefault parses a default tag value as type t, with bare numbers
+// for a Duration or time.Duration in unit.
+func parseDefault(s string, t reflect.Type, unit time.Duration) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if t == durationType || t == configDurationType {
+		d, err := parseDuration(s, unit)
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(int64(d))
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		parts := strings.Split(s, ",")
+		v = reflect.MakeSlice(t, 0, len(parts))
+		for _, p := range parts {
+			ev, err := parseDefault(strings.TrimSpace(p), t.Elem(), unit)
+			if err != nil {
+				return v, err
+			}
+			v = reflect.Append(v, ev)
+		}
+	default:
+		return v, fmt.Errorf("unsupported type %s", t)
+	}
+	return v, nil
+}
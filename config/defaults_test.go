@@ -0,0 +1,172 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	type Limits struct {
+		Burst int `config:"burst" default:"10"`
+	}
+	type AppConfig struct {
+		Addr    string        `config:"addr" default:":8080"`
+		Debug   bool          `config:"debug" default:"true"`
+		Ratio   float64       `config:"ratio" default:"0.5"`
+		Timeout time.Duration `config:"timeout" default:"5s"`
+		Tags    []string      `config:"tags" default:"a, b"`
+		Name    string        `config:"name"`
+		Limits  Limits        `config:"limits"`
+		Extra   *Limits       `config:"extra"`
+	}
+
+	t.Run("fills zero fields", func(t *testing.T) {
+		cfg := AppConfig{Extra: &Limits{}}
+		if _, err := config.ApplyDefaults(&cfg); err != nil {
+			t.Fatalf("ApplyDefaults() error = %v", err)
+		}
+		want := AppConfig{
+			Addr:    ":8080",
+			Debug:   true,
+			Ratio:   0.5,
+			Timeout: 5 * time.Second,
+			Tags:    []string{"a", "b"},
+			Limits:  Limits{Burst: 10},
+			Extra:   &Limits{Burst: 10},
+		}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("ApplyDefaults() got = %+v, want %+v", cfg, want)
+		}
+	})
+
+	t.Run("keeps set fields", func(t *testing.T) {
+		cfg := AppConfig{Addr: ":9090", Limits: Limits{Burst: 3}}
+		applied, err := config.ApplyDefaults(&cfg)
+		if err != nil {
+			t.Fatalf("ApplyDefaults() error = %v", err)
+		}
+		if cfg.Addr != ":9090" || cfg.Limits.Burst != 3 {
+			t.Errorf("ApplyDefaults() overwrote set fields: %+v", cfg)
+		}
+		want := []string{"debug", "ratio", "timeout", "tags"}
+		if !reflect.DeepEqual(applied, want) {
+			t.Errorf("ApplyDefaults() applied = %v, want %v", applied, want)
+		}
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		var cfg struct {
+			Port int `default:"http"`
+		}
+		if _, err := config.ApplyDefaults(&cfg); err == nil {
+			t.Error("ApplyDefaults() with unparsable default: expected error")
+		}
+	})
+
+	t.Run("non-pointer", func(t *testing.T) {
+		if _, err := config.ApplyDefaults(AppConfig{}); err == nil {
+			t.Error("ApplyDefaults() with non-pointer: expected error")
+		}
+	})
+}
+
+func TestBinder_Bind_DefaultsBeforeValidation(t *testing.T) {
+	type AppConfig struct {
+		Addr string `config:"addr" default:":8080" validate:"required"`
+		Name string `config:"name" validate:"required"`
+	}
+
+	var cfg AppConfig
+	if err := config.NewBinder().Bind(map[string]any{"name": "orders"}, &cfg); err != nil {
+		t.Fatalf("Bind() error = %v, want defaulted addr to satisfy required", err)
+	}
+	if cfg.Addr != ":8080" {
+		t.Errorf("Addr = %q, want %q", cfg.Addr, ":8080")
+	}
+
+	// Fields without a default still fail validation.
+	var missing AppConfig
+	err := config.NewBinder().Bind(map[string]any{}, &missing)
+	var bindErr *config.BindError
+	if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+		t.Errorf("Bind() error = %v, want validate BindError", err)
+	}
+}
+
+func TestBinder_Bind_Defaults(t *testing.T) {
+	type Limits struct {
+		Burst int `config:"burst" default:"10"`
+	}
+	type AppConfig struct {
+		Addr    string        `config:"addr" default:":8080"`
+		Workers int           `config:"workers" default:"4"`
+		Debug   bool          `config:"debug" default:"true"`
+		Timeout time.Duration `config:"timeout" default:"5s"`
+		Tags    []string      `config:"tags" default:"a, b"`
+		Limits  Limits        `config:"limits"`
+	}
+	defaults := AppConfig{
+		Addr:    ":8080",
+		Workers: 4,
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Tags:    []string{"a", "b"},
+		Limits:  Limits{Burst: 10},
+	}
+
+	tests := []struct {
+		name   string
+		source map[string]any
+		want   func(c *AppConfig)
+	}{
+		{
+			name:   "absent keys get defaults",
+			source: map[string]any{},
+			want:   func(c *AppConfig) {},
+		},
+		{
+			name: "explicit values override",
+			source: map[string]any{
+				"addr": ":9090", "workers": "8", "debug": "false", "timeout": "1m",
+				"tags": "x,y,z", "limits": map[string]any{"burst": 3},
+			},
+			want: func(c *AppConfig) {
+				*c = AppConfig{Addr: ":9090", Workers: 8, Timeout: time.Minute, Tags: []string{"x", "y", "z"}, Limits: Limits{Burst: 3}}
+			},
+		},
+		{
+			name: "explicit zero values override",
+			source: map[string]any{
+				"addr": "", "workers": 0, "debug": false, "timeout": "0s",
+				"tags": []any{}, "limits": map[string]any{"burst": 0},
+			},
+			want: func(c *AppConfig) { *c = AppConfig{Tags: []string{}} },
+		},
+		{
+			name:   "keys match case-insensitively",
+			source: map[string]any{"DEBUG": false, "Limits": map[string]any{"BURST": 0}},
+			want: func(c *AppConfig) {
+				c.Debug = false
+				c.Limits.Burst = 0
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got AppConfig
+			if err := config.NewBinder().Bind(tt.source, &got); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			want := defaults
+			want.Tags = append([]string(nil), defaults.Tags...)
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Bind() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
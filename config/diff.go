@@ -1,6 +1,12 @@
 package config
 
-import "reflect"
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 func diffEvent(old, new any) Event {
 	var changedKeys []string
@@ -44,3 +50,133 @@ func diffEvent(old, new any) Event {
 		NewConfig:   new,
 	}
 }
+
+// DiffString describes the fields that differ between two config structs
+// (or pointers to them) of the same type, one line per field, with the
+// dotted config path and its old and new values:
+//
+//	server.addr: ":8080" -> ":9090"
+//	server.readTimeout: 5s -> 10s
+//
+// Nested structs are walked field by field, in declaration order; other
+// values (slices, maps) are compared and printed whole. It returns "" if
+// nothing changed, or if old and new aren't structs of the same type.
+// Values are printed as is, so don't log diffs of secret fields.
+func DiffString(old, new any) string {
+	oldV, newV := reflect.ValueOf(old), reflect.ValueOf(new)
+	if !oldV.IsValid() || !newV.IsValid() || oldV.Type() != newV.Type() ||
+		indirectType(oldV.Type()).Kind() != reflect.Struct {
+		return ""
+	}
+	var b strings.Builder
+	diffFields(&b, oldV, newV, "")
+	return b.String()
+}
+
+func diffFields(b *strings.Builder, oldV, newV reflect.Value, prefix string) {
+	for oldV.Kind() == reflect.Ptr {
+		if oldV.IsNil() || newV.IsNil() {
+			if oldV.IsNil() != newV.IsNil() {
+				writeDiffLine(b, prefix, oldV, newV)
+			}
+			return
+		}
+		oldV, newV = oldV.Elem(), newV.Elem()
+	}
+	// Structs with their own text form (time.Time, net.IP wrappers) are
+	// values, not groups of config fields.
+	if oldV.Kind() != reflect.Struct || oldV.Type().Implements(textMarshalerType) {
+		if !reflect.DeepEqual(oldV.Interface(), newV.Interface()) {
+			writeDiffLine(b, prefix, oldV, newV)
+		}
+		return
+	}
+
+	t := oldV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			diffFields(b, oldV.Field(i), newV.Field(i), prefix)
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		diffFields(b, oldV.Field(i), newV.Field(i), joinPath(prefix, key))
+	}
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+func writeDiffLine(b *strings.Builder, path string, oldV, newV reflect.Value) {
+	fmt.Fprintf(b, "%s: %s -> %s\n", path, diffValue(oldV), diffValue(newV))
+}
+
+// diffValue formats v for DiffString, quoting strings so empty and
+// whitespace values are visible.
+func diffValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "nil"
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// DiffMaps compares two merged config maps and returns the dotted paths of
+// keys that were added, removed, or changed, each sorted.
+//
+// Nested maps are walked recursively, so a map entry disappearing shows up
+// as a removed "limits.gold" rather than a changed "limits". A key whose
+// value switches between a map and a leaf is reported as changed; other
+// values (including slices) are compared whole.
+//
+// Example:
+//
+//	old := map[string]any{"server": map[string]any{"addr": ":8080", "debug": true}}
+//	new := map[string]any{"server": map[string]any{"addr": ":9090", "mode": "test"}}
+//	added, removed, changed := config.DiffMaps(old, new)
+//	// added = [server.mode], removed = [server.debug], changed = [server.addr]
+func DiffMaps(old, new map[string]any) (added, removed, changed []string) {
+	diffMaps(old, new, "", &added, &removed, &changed)
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+func diffMaps(old, new map[string]any, prefix string, added, removed, changed *[]string) {
+	for k, ov := range old {
+		path := joinPath(prefix, k)
+		nv, ok := new[k]
+		if !ok {
+			*removed = append(*removed, path)
+			continue
+		}
+		om, oIsMap := ov.(map[string]any)
+		nm, nIsMap := nv.(map[string]any)
+		switch {
+		case oIsMap && nIsMap:
+			diffMaps(om, nm, path, added, removed, changed)
+		case !reflect.DeepEqual(ov, nv):
+			*changed = append(*changed, path)
+		}
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			*added = append(*added, joinPath(prefix, k))
+		}
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -516,3 +517,123 @@ func BenchmarkDiffEvent(b *testing.B) {
 		})
 	}
 }
+
+func TestDiffMaps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		old, new    map[string]any
+		wantAdded   []string
+		wantRemoved []string
+		wantChanged []string
+	}{
+		{
+			name: "identical maps",
+			old:  map[string]any{"a": 1, "b": map[string]any{"c": "x"}},
+			new:  map[string]any{"a": 1, "b": map[string]any{"c": "x"}},
+		},
+		{
+			name:        "top-level changes",
+			old:         map[string]any{"name": "orders", "debug": true},
+			new:         map[string]any{"name": "billing", "port": 8080},
+			wantAdded:   []string{"port"},
+			wantRemoved: []string{"debug"},
+			wantChanged: []string{"name"},
+		},
+		{
+			name: "nested changes",
+			old: map[string]any{
+				"server": map[string]any{"addr": ":8080", "tls": map[string]any{"enabled": false, "cert": "a.pem"}},
+				"limits": map[string]any{"gold": 10, "silver": 5},
+			},
+			new: map[string]any{
+				"server": map[string]any{"addr": ":8080", "tls": map[string]any{"enabled": true, "key": "a.key"}},
+				"limits": map[string]any{"gold": 10},
+			},
+			wantAdded:   []string{"server.tls.key"},
+			wantRemoved: []string{"limits.silver", "server.tls.cert"},
+			wantChanged: []string{"server.tls.enabled"},
+		},
+		{
+			name:        "whole subtree added and removed",
+			old:         map[string]any{"cache": map[string]any{"ttl": "5m"}},
+			new:         map[string]any{"db": map[string]any{"host": "localhost"}},
+			wantAdded:   []string{"db"},
+			wantRemoved: []string{"cache"},
+		},
+		{
+			name:        "map replaced by leaf",
+			old:         map[string]any{"db": map[string]any{"host": "localhost"}},
+			new:         map[string]any{"db": "postgres://localhost"},
+			wantChanged: []string{"db"},
+		},
+		{
+			name:        "slices compared whole",
+			old:         map[string]any{"tags": []any{"a", "b"}},
+			new:         map[string]any{"tags": []any{"a", "c"}},
+			wantChanged: []string{"tags"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			added, removed, changed := DiffMaps(tt.old, tt.new)
+			assert.Equal(t, tt.wantAdded, added, "added")
+			assert.Equal(t, tt.wantRemoved, removed, "removed")
+			assert.Equal(t, tt.wantChanged, changed, "changed")
+		})
+	}
+}
+
+func TestDiffString(t *testing.T) {
+	t.Parallel()
+
+	type Pool struct {
+		Max int `config:"max"`
+	}
+	type DB struct {
+		Host string `config:"host"`
+		Pool Pool   `config:"pool"`
+	}
+	type AppConfig struct {
+		Name    string        `config:"name"`
+		Timeout time.Duration `config:"timeout"`
+		Tags    []string      `config:"tags"`
+		DB      DB            `config:"db"`
+		Started time.Time     `config:"started"`
+		Cache   *Pool         `config:"cache"`
+	}
+
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	old := AppConfig{
+		Name:    "orders",
+		Timeout: 5 * time.Second,
+		Tags:    []string{"a"},
+		DB:      DB{Host: "db1", Pool: Pool{Max: 10}},
+		Started: started,
+	}
+	new := old
+	new.Timeout = 10 * time.Second
+	new.Tags = []string{"a", "b"}
+	new.DB.Pool.Max = 50
+	new.Started = started.Add(time.Hour)
+	new.Cache = &Pool{Max: 1}
+
+	want := "timeout: 5s -> 10s\n" +
+		"tags: [a] -> [a b]\n" +
+		"db.pool.max: 10 -> 50\n" +
+		"started: 2024-01-02 03:04:05 +0000 UTC -> 2024-01-02 04:04:05 +0000 UTC\n" +
+		"cache: nil -> {1}\n"
+	assert.Equal(t, want, DiffString(old, new))
+	assert.Equal(t, want, DiffString(&old, &new))
+
+	changedHost := old
+	changedHost.DB.Host = ""
+	assert.Equal(t, "db.host: \"db1\" -> \"\"\n", DiffString(old, changedHost))
+
+	assert.Empty(t, DiffString(old, old))
+	assert.Empty(t, DiffString(old, 42))
+	assert.Empty(t, DiffString(1, 2))
+}
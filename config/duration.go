@@ -0,0 +1,156 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDurationUnit is the unit of bare numbers bound to a Duration or
+// time.Duration field, so `timeout: 30` means 30 seconds rather than 30
+// nanoseconds. A Binder can use another with WithDurationUnit
+// (Options.DurationUnit for a Manager).
+const DefaultDurationUnit = time.Second
+
+// Duration is a time.Duration that also accepts bare numbers in the
+// Binder's duration unit, DefaultDurationUnit unless set.
+//
+// Strings use time.ParseDuration formats, as time.Duration fields do:
+//
+//	timeout: 1h30m   # 90 minutes
+//	timeout: 500ms
+//	timeout: 30      # 30 seconds with the default unit
+//	timeout: "30"    # same
+//	timeout: 1.5     # 1.5 seconds
+//
+// The Binder, UnmarshalYAML and UnmarshalJSON parse values the same way,
+// so a Duration reads the same whether it comes from a source map or a
+// YAML or JSON document; the unmarshalers, which have no Binder, take bare
+// numbers in DefaultDurationUnit. It marshals back to a duration string
+// ("30s").
+//
+// The Binder reads time.Duration fields the same way; Duration adds the
+// YAML and JSON handling.
+type Duration time.Duration
+
+var configDurationType = reflect.TypeOf(Duration(0))
+
+// Std returns d as a time.Duration.
+func (d Duration) Std() time.Duration { return time.Duration(d) }
+
+// String formats d like time.Duration.
+func (d Duration) String() string { return time.Duration(d).String() }
+
+// MarshalJSON renders d as a duration string like "30s", the format it's
+// written in, rather than a number of nanoseconds.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON parses a duration string or a bare number in
+// DefaultDurationUnit.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		parsed, err := parseDuration(v, DefaultDurationUnit)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	case float64:
+		parsed, err := durationOf(v, DefaultDurationUnit)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	default:
+		return fmt.Errorf("config: duration must be a string or number, got %s", data)
+	}
+	return nil
+}
+
+// MarshalYAML renders d as a duration string like "30s".
+func (d Duration) MarshalYAML() (any, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML parses a duration string or a bare number in
+// DefaultDurationUnit.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("config: duration must be a scalar, got %s", node.Tag)
+	}
+	parsed, err := parseDuration(node.Value, DefaultDurationUnit)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// parseDuration parses s as a bare number in unit or a time.ParseDuration
+// string.
+func parseDuration(s string, unit time.Duration) (Duration, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return durationOf(f, unit)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("config: invalid duration %q", s)
+	}
+	return Duration(d), nil
+}
+
+// durationOf returns f units as a Duration. NaN, infinities and values out
+// of the Duration range are errors rather than garbage durations.
+func durationOf(f float64, unit time.Duration) (Duration, error) {
+	d := f * float64(unit)
+	if math.IsNaN(d) || d >= math.MaxInt64 || d <= math.MinInt64 {
+		return 0, fmt.Errorf("config: invalid duration %v", f)
+	}
+	return Duration(d), nil
+}
+
+// durationHookFunc decodes strings and numbers into Duration and
+// time.Duration fields, bare numbers being in unit. Values that already
+// are durations are kept as they are.
+func durationHookFunc(unit time.Duration) func(from, to reflect.Type, data any) (any, error) {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if to != configDurationType && to != durationType {
+			return data, nil
+		}
+		if from == configDurationType || from == durationType {
+			return data, nil
+		}
+		var (
+			d   Duration
+			err error
+		)
+		v := reflect.ValueOf(data)
+		switch from.Kind() {
+		case reflect.String:
+			d, err = parseDuration(v.String(), unit)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			d, err = durationOf(float64(v.Int()), unit)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			d, err = durationOf(float64(v.Uint()), unit)
+		case reflect.Float32, reflect.Float64:
+			d, err = durationOf(v.Float(), unit)
+		default:
+			return data, nil
+		}
+		if err != nil || to == configDurationType {
+			return d, err
+		}
+		return time.Duration(d), nil
+	}
+}
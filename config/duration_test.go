@@ -0,0 +1,219 @@
+package config_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want time.Duration
+	}{
+		{"compound", "1h30m", 90 * time.Minute},
+		{"milliseconds", "500ms", 500 * time.Millisecond},
+		{"bare integer", "30", 30 * time.Second},
+		{"quoted integer", `"30"`, 30 * time.Second},
+		{"bare float", "1.5", 1500 * time.Millisecond},
+	}
+
+	type AppConfig struct {
+		Timeout config.Duration `config:"timeout" yaml:"timeout"`
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := []byte("timeout: " + tt.yaml)
+
+			var viaYAML AppConfig
+			if err := yaml.Unmarshal(doc, &viaYAML); err != nil {
+				t.Fatalf("yaml.Unmarshal() error = %v", err)
+			}
+			if got := viaYAML.Timeout.Std(); got != tt.want {
+				t.Errorf("UnmarshalYAML got = %v, want %v", got, tt.want)
+			}
+
+			var viaBinder AppConfig
+			if err := config.BindBytes(doc, "yaml", &viaBinder); err != nil {
+				t.Fatalf("BindBytes() error = %v", err)
+			}
+			if got := viaBinder.Timeout.Std(); got != tt.want {
+				t.Errorf("Bind got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Invalid(t *testing.T) {
+	var cfg struct {
+		Timeout config.Duration `yaml:"timeout"`
+	}
+	if err := yaml.Unmarshal([]byte("timeout: soon"), &cfg); err == nil {
+		t.Error("yaml.Unmarshal() with invalid duration: expected error")
+	}
+}
+
+func TestDuration_JSON(t *testing.T) {
+	type Config struct {
+		Timeout config.Duration `json:"timeout"`
+	}
+
+	out, err := json.Marshal(Config{Timeout: config.Duration(30 * time.Second)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(out), `{"timeout":"30s"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	for _, in := range []string{`{"timeout":"30s"}`, `{"timeout":30}`} {
+		var got Config
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", in, err)
+		}
+		if got.Timeout.Std() != 30*time.Second {
+			t.Errorf("Unmarshal(%s) = %v, want 30s", in, got.Timeout)
+		}
+	}
+
+	var bad Config
+	if err := json.Unmarshal([]byte(`{"timeout":true}`), &bad); err == nil {
+		t.Error("Unmarshal() of a bool: expected error")
+	}
+}
+
+func TestDuration_MarshalYAML(t *testing.T) {
+	out, err := yaml.Marshal(map[string]config.Duration{"timeout": config.Duration(90 * time.Minute)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(out), "timeout: 1h30m0s\n"; got != want {
+		t.Errorf("Marshal() = %q, want %q", got, want)
+	}
+}
+
+func TestDuration_Unit(t *testing.T) {
+	type Config struct {
+		Timeout config.Duration `config:"timeout"`
+		Grace   config.Duration `config:"grace" default:"40"`
+	}
+
+	var cfg Config
+	if err := config.NewBinderWith(config.WithDurationUnit(time.Millisecond)).Bind(map[string]any{"timeout": 250}, &cfg); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if got := cfg.Timeout.Std(); got != 250*time.Millisecond {
+		t.Errorf("Timeout = %v, want 250ms", got)
+	}
+	if got := cfg.Grace.Std(); got != 40*time.Millisecond {
+		t.Errorf("Grace = %v, want the default in the Binder's unit, 40ms", got)
+	}
+
+	// Other Binders keep the default unit.
+	var other Config
+	if err := config.NewBinder().Bind(map[string]any{"timeout": 250}, &other); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if got := other.Timeout.Std(); got != 250*time.Second {
+		t.Errorf("Timeout = %v, want 250s", got)
+	}
+
+	var viaManager Config
+	manager, err := config.NewManager(&viaManager, config.Options{DurationUnit: time.Minute},
+		&mockSource{name: "file", data: map[string]any{"timeout": "2"}})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+	if got := viaManager.Timeout.Std(); got != 2*time.Minute {
+		t.Errorf("Timeout = %v, want 2m with Options.DurationUnit", got)
+	}
+}
+
+func TestDuration_StdField(t *testing.T) {
+	type Config struct {
+		Timeout  config.Duration `config:"timeout"`
+		Interval time.Duration   `config:"interval"`
+		Grace    config.Duration `config:"grace" default:"40"`
+		Backoff  time.Duration   `config:"backoff" default:"40"`
+	}
+	source := map[string]any{"timeout": 30, "interval": "30"}
+
+	for _, tt := range []struct {
+		name   string
+		binder *config.Binder
+		unit   time.Duration
+	}{
+		{"default unit", config.NewBinder(), time.Second},
+		{"WithDurationUnit", config.NewBinderWith(config.WithDurationUnit(time.Millisecond)), time.Millisecond},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			if err := tt.binder.Bind(source, &cfg); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if got, want := cfg.Timeout.Std(), 30*tt.unit; got != want {
+				t.Errorf("Timeout = %v, want %v", got, want)
+			}
+			if got, want := cfg.Interval, 30*tt.unit; got != want {
+				t.Errorf("Interval = %v, want %v", got, want)
+			}
+			if got, want := cfg.Grace.Std(), 40*tt.unit; got != want {
+				t.Errorf("Grace = %v, want %v", got, want)
+			}
+			if got, want := cfg.Backoff, 40*tt.unit; got != want {
+				t.Errorf("Backoff = %v, want %v", got, want)
+			}
+		})
+	}
+
+	var cfg Config
+	manager, err := config.NewManager(&cfg, config.Options{DurationUnit: time.Minute},
+		&mockSource{name: "file", data: map[string]any{"timeout": 2, "interval": 2}})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+	if cfg.Interval != 2*time.Minute {
+		t.Errorf("Interval = %v, want 2m with Options.DurationUnit", cfg.Interval)
+	}
+	if got := manager.GetDuration("interval"); got != 2*time.Minute {
+		t.Errorf("GetDuration(interval) = %v, want 2m", got)
+	}
+}
+
+func TestDuration_NonFinite(t *testing.T) {
+	var cfg struct {
+		Timeout config.Duration `config:"timeout"`
+	}
+	for _, v := range []any{"NaN", "Inf", "-inf", "1e300", math.NaN(), math.Inf(1)} {
+		if err := config.NewBinder().Bind(map[string]any{"timeout": v}, &cfg); err == nil {
+			t.Errorf("Bind(%v) = %v, want error", v, cfg.Timeout)
+		}
+	}
+	var viaYAML struct {
+		Timeout config.Duration `yaml:"timeout"`
+	}
+	if err := yaml.Unmarshal([]byte("timeout: .nan"), &viaYAML); err == nil {
+		t.Error("yaml.Unmarshal() of .nan: expected error")
+	}
+}
+
+func TestApplyDefaults_Duration(t *testing.T) {
+	var cfg struct {
+		Timeout config.Duration `default:"2m"`
+	}
+	if _, err := config.ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	if got := cfg.Timeout.Std(); got != 2*time.Minute {
+		t.Errorf("Timeout = %v, want 2m", got)
+	}
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateExample renders a starting-point config file for the struct cfg
+// (a struct, a pointer to one, or a reflect.Type), listing every field
+// under its config key with its `default` tag value, or the zero value if
+// it has none. format is "yaml", "yml" or "json".
+//
+// In YAML, each field carries a comment saying whether it's required and
+// listing its other validate rules:
+//
+//	server:
+//	  addr: :8080 # required
+//	  port: 8080 # validate: min=1,max=65535
+//	  readTimeout: 0s
+//
+// JSON has no comments, so only the values are emitted. Keys are in field
+// order either way. It can back a "config init" command that writes the
+// template for users to fill in.
+//
+// Returns an error for an unsupported format, a cfg that isn't a struct,
+// or an unparsable default tag.
+func GenerateExample(cfg any, format string) ([]byte, error) {
+	t, ok := cfg.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(cfg)
+	}
+	if t == nil || indirectType(t).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: GenerateExample requires a struct, got %v", t)
+	}
+	fields, err := exampleFields(indirectType(t))
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "yaml", "yml":
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		node, err := exampleYAML(fields)
+		if err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(node); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "json":
+		var buf bytes.Buffer
+		if err := exampleJSON(&buf, fields); err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+			return nil, err
+		}
+		out.WriteByte('\n')
+		return out.Bytes(), nil
+	}
+	return nil, fmt.Errorf("config: unsupported format %q", format)
+}
+
+// exampleField is a field of the generated example: a leaf value, or a
+// nested struct's fields.
+type exampleField struct {
+	key     string
+	value   any
+	nested  []exampleField
+	comment string
+}
+
+func exampleFields(t reflect.Type) ([]exampleField, error) {
+	var out []exampleField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			inner, err := exampleFields(f.Type)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, inner...)
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+
+		field := exampleField{key: key, comment: exampleComment(f.Tag.Get("validate"))}
+		ft := indirectType(f.Type)
+		switch tag, hasDefault := f.Tag.Lookup("default"); {
+		case hasDefault:
+			v, err := parseDefault(tag, f.Type, DefaultDurationUnit)
+			if err != nil {
+				return nil, fmt.Errorf("config: default for %s.%s: %w", t.Name(), f.Name, err)
+			}
+			field.value = exampleValue(v)
+		case IsNested(ft):
+			nested, err := exampleFields(ft)
+			if err != nil {
+				return nil, err
+			}
+			field.nested = nested
+			if nested == nil {
+				field.nested = []exampleField{}
+			}
+		default:
+			field.value = exampleValue(reflect.Zero(ft))
+		}
+		out = append(out, field)
+	}
+	return out, nil
+}
+
+// exampleComment describes a validate tag: "required", then other rules.
+func exampleComment(validate string) string {
+	var required bool
+	var rules []string
+	for _, rule := range strings.Split(validate, ",") {
+		switch rule {
+		case "":
+		case "required":
+			required = true
+		default:
+			rules = append(rules, rule)
+		}
+	}
+	var parts []string
+	if required {
+		parts = append(parts, "required")
+	}
+	if len(rules) > 0 {
+		parts = append(parts, "validate: "+strings.Join(rules, ","))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// exampleValue converts v to what a config file would hold for it.
+func exampleValue(v reflect.Value) any {
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Type() == configDurationType:
+		return Duration(v.Int()).String()
+	case v.Type().Implements(textMarshalerType):
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = exampleValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		return map[string]any{}
+	case reflect.Ptr:
+		return exampleValue(reflect.Zero(v.Type().Elem()))
+	}
+	return v.Interface()
+}
+
+func exampleYAML(fields []exampleField) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range fields {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: f.key}
+		var value *yaml.Node
+		if f.nested != nil {
+			var err error
+			if value, err = exampleYAML(f.nested); err != nil {
+				return nil, err
+			}
+			if f.comment != "" {
+				key.LineComment = "# " + f.comment
+			}
+		} else {
+			value = &yaml.Node{}
+			if err := value.Encode(f.value); err != nil {
+				return nil, err
+			}
+			if f.comment != "" {
+				value.LineComment = "# " + f.comment
+			}
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
+
+func exampleJSON(buf *bytes.Buffer, fields []exampleField) error {
+	buf.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		if f.nested != nil {
+			if err := exampleJSON(buf, f.nested); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,85 @@
+package config_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/skekre98/genever/config"
+)
+
+type exampleConfig struct {
+	Name   string `config:"name" validate:"required"`
+	Server struct {
+		Port    int           `config:"port" default:"8080" validate:"min=1,max=65535"`
+		Timeout time.Duration `config:"timeout" default:"30s"`
+		Tags    []string      `config:"tags" default:"web,api"`
+	} `config:"server"`
+	Retry config.Duration `config:"retry"`
+	Debug bool            `config:"debug"`
+}
+
+func TestGenerateExample_YAML(t *testing.T) {
+	out, err := config.GenerateExample(&exampleConfig{}, "yaml")
+	if err != nil {
+		t.Fatalf("GenerateExample() error = %v", err)
+	}
+	got := string(out)
+
+	for _, line := range []string{
+		`name: "" # required`,
+		"port: 8080 # validate: min=1,max=65535",
+		"timeout: 30s",
+		"retry: 0s",
+		"debug: false",
+		"- web",
+		"- api",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("example missing %q:\n%s", line, got)
+		}
+	}
+
+	// The template binds back to the defaults.
+	var m map[string]any
+	if err := yaml.Unmarshal(out, &m); err != nil {
+		t.Fatalf("example is not valid YAML: %v", err)
+	}
+	m["name"] = "orders"
+	var cfg exampleConfig
+	if err := config.NewBinder().Bind(m, &cfg); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if cfg.Server.Port != 8080 || cfg.Server.Timeout != 30*time.Second || len(cfg.Server.Tags) != 2 {
+		t.Errorf("bound example = %+v", cfg)
+	}
+}
+
+func TestGenerateExample_JSON(t *testing.T) {
+	out, err := config.GenerateExample(exampleConfig{}, "json")
+	if err != nil {
+		t.Fatalf("GenerateExample() error = %v", err)
+	}
+	if !strings.HasPrefix(string(out), "{\n  \"name\": \"\",\n  \"server\": {") {
+		t.Errorf("keys not in field order:\n%s", out)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(out, &m); err != nil {
+		t.Fatalf("example is not valid JSON: %v", err)
+	}
+	if port := m["server"].(map[string]any)["port"]; port != 8080.0 {
+		t.Errorf("server.port = %v, want 8080", port)
+	}
+}
+
+func TestGenerateExample_Errors(t *testing.T) {
+	if _, err := config.GenerateExample(exampleConfig{}, "toml"); err == nil {
+		t.Error("GenerateExample() with an unsupported format: want error")
+	}
+	if _, err := config.GenerateExample("not a struct", "yaml"); err == nil {
+		t.Error("GenerateExample() with a non-struct: want error")
+	}
+}
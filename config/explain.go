@@ -0,0 +1,200 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+)
+
+// layer is one source's data from a Reload, kept for Explain.
+type layer struct {
+	name string
+	data map[string]any
+}
+
+// Snapshot returns a copy of the merged map of all sources from the last
+// successful Reload, before it was bound into the config struct, or nil
+// before the first load. Comparing it with the struct shows what the
+// Binder did with each value; Explain shows which source a value came from.
+// The copy is deep, so callers may modify it.
+func (m *Manager) Snapshot() map[string]any {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.merged == nil {
+		return nil
+	}
+	return cloneMap(m.merged)
+}
+
+// Origins returns, for each leaf of the merged map from the last successful
+// Reload, keyed by dotted path, the name of the source whose value won,
+// e.g. "server.port" -> "cli". Leaves that only get a default tag's value
+// aren't included; see Defaulted.
+func (m *Manager) Origins() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.origins)
+}
+
+// Explain reports how the config value at a dotted path (e.g.
+// "server.readTimeout") was resolved: what each source provided in the last
+// Reload, the merged value, the bound field value, and why the field is or
+// isn't set. It's a debugging aid for values that come out unexpectedly
+// zero; the format is meant for people and may change.
+//
+// Example output:
+//
+//	server.readTimeout
+//	  file: 5s
+//	  env: 10s
+//	  cli: not set
+//	  merged: 10s
+//	  field: 10s
+//	  result: set by env (overrides file)
+func (m *Manager) Explain(path string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var b strings.Builder
+	b.WriteString(path + "\n")
+
+	var setBy []string
+	for _, l := range m.layers {
+		v, ok := lookupPath(l.data, path)
+		if !ok {
+			fmt.Fprintf(&b, "  %s: not set\n", l.name)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %v\n", l.name, v)
+		setBy = append(setBy, l.name)
+	}
+
+	if v, ok := lookupPath(m.merged, path); ok {
+		fmt.Fprintf(&b, "  merged: %v\n", v)
+	} else {
+		b.WriteString("  merged: not set\n")
+	}
+
+	field, inSchema := fieldValue(reflect.ValueOf(m.config), path)
+	if inSchema {
+		fmt.Fprintf(&b, "  field: %v\n", field.Interface())
+	} else {
+		b.WriteString("  field: no such field in the config struct\n")
+	}
+
+	b.WriteString("  result: " + m.explainResult(path, setBy, field, inSchema) + "\n")
+	return b.String()
+}
+
+// explainResult summarizes why the field at path has its value.
+func (m *Manager) explainResult(path string, setBy []string, field reflect.Value, inSchema bool) string {
+	if err := m.last.Err; err != nil && rejects(err, reflect.TypeOf(m.config), path) {
+		return fmt.Sprintf("the last reload rejected this value, so the previous config is in use: %v", err)
+	}
+	if len(setBy) == 0 {
+		if slices.Contains(m.defaulted, path) {
+			return "not present in any source; filled from its default tag"
+		}
+		return "not present in any source"
+	}
+
+	winner := setBy[len(setBy)-1]
+	result := "set by " + winner
+	if len(setBy) > 1 {
+		result += " (overrides " + strings.Join(setBy[:len(setBy)-1], ", ") + ")"
+	}
+	switch {
+	case !inSchema:
+		result += ", but no config field binds it"
+	case field.IsZero():
+		result += ", but the field is zero: check the value's type and format"
+	}
+	return result
+}
+
+// rejects reports whether err, from a failed Reload into a t, rejected the
+// value at path: its field failed validation, or decoding it failed.
+func rejects(err error, t reflect.Type, path string) bool {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		for _, fe := range verrs {
+			if p, ok := configPath(t, fe.StructNamespace()); ok && strings.EqualFold(strings.Join(p, "."), path) {
+				return true
+			}
+		}
+		return false
+	}
+	// mapstructure reports decode failures only as messages, each naming
+	// the field first, in quotes: "cannot parse 'server.port' as int".
+	var derr *mapstructure.Error
+	if errors.As(err, &derr) {
+		for _, msg := range derr.Errors {
+			_, rest, _ := strings.Cut(msg, "'")
+			if name, _, ok := strings.Cut(rest, "'"); ok && strings.EqualFold(name, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldValue returns the struct field of cfg at a dotted path of config
+// keys, descending into nested structs and string-keyed maps.
+func fieldValue(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, key := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			f, ok := lookupField(v.Type(), key)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v = v.FieldByIndex(f.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+// cloneMap returns a deep copy of the nested maps and slices in m.
+func cloneMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = cloneValue(v)
+	}
+	return out
+}
+
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return cloneMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = cloneValue(e)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,220 @@
+package config_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestManager_Explain(t *testing.T) {
+	type ServerConfig struct {
+		Addr        string `config:"addr" default:":8080"`
+		ReadTimeout string `config:"readTimeout"`
+		Port        int    `config:"port"`
+	}
+	type AppConfig struct {
+		Server ServerConfig `config:"server"`
+		Name   string       `config:"name"`
+	}
+
+	file := &mockSource{name: "file", data: map[string]any{
+		"server": map[string]any{"readTimeout": "5s", "port": 8080},
+	}}
+	env := &stringSource{mockSource{name: "env", data: map[string]any{
+		"server": map[string]any{"readTimeout": "10s"},
+	}}}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, file, env)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "missing everywhere",
+			path: "name",
+			want: []string{"file: not set", "env: not set", "merged: not set", "result: not present in any source"},
+		},
+		{
+			name: "overridden by env",
+			path: "server.readTimeout",
+			want: []string{"file: 5s", "env: 10s", "merged: 10s", "field: 10s", "result: set by env (overrides file)"},
+		},
+		{
+			name: "default",
+			path: "server.addr",
+			want: []string{"field: :8080", "filled from its default tag"},
+		},
+		{
+			name: "unknown field",
+			path: "server.tls",
+			want: []string{"no such field in the config struct"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := manager.Explain(tt.path)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Explain(%q) missing %q:\n%s", tt.path, want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("rejected value", func(t *testing.T) {
+		env.mu.Lock()
+		env.data = map[string]any{"server": map[string]any{"port": "http"}}
+		env.mu.Unlock()
+		if err := manager.Reload(context.Background()); err == nil {
+			t.Fatal("Reload() with invalid port: expected error")
+		}
+
+		got := manager.Explain("server.port")
+		for _, want := range []string{"env: http", "field: 8080", "the last reload rejected this value"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("Explain() missing %q:\n%s", want, got)
+			}
+		}
+	})
+}
+
+func TestManager_ExplainRejectedField(t *testing.T) {
+	type AppConfig struct {
+		Workers int `config:"workers" validate:"max=10"`
+		Max     int `config:"max"`
+	}
+
+	src := &mockSource{name: "file", data: map[string]any{"workers": 4, "max": 3}}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, src)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	src.mu.Lock()
+	src.data = map[string]any{"workers": 99, "max": 3}
+	src.mu.Unlock()
+	if err := manager.Reload(context.Background()); err == nil {
+		t.Fatal("Reload() with too many workers: expected error")
+	}
+
+	if got := manager.Explain("workers"); !strings.Contains(got, "the last reload rejected this value") {
+		t.Errorf("Explain(workers) should report the rejection:\n%s", got)
+	}
+	// The failed rule is named 'max' too, but the max field is fine.
+	if got := manager.Explain("max"); strings.Contains(got, "rejected") {
+		t.Errorf("Explain(max) reports a rejection of another field:\n%s", got)
+	}
+}
+
+func TestManager_Snapshot(t *testing.T) {
+	type ServerConfig struct {
+		Addr string `config:"addr"`
+		Port int    `config:"port"`
+	}
+	type AppConfig struct {
+		Server ServerConfig `config:"server"`
+		Name   string       `config:"name"`
+	}
+
+	file := &mockSource{name: "file", data: map[string]any{
+		"name":   "orders",
+		"server": map[string]any{"addr": "0.0.0.0", "port": 8080},
+	}}
+	env := &mockSource{name: "env", data: map[string]any{
+		"server": map[string]any{"port": 9090},
+	}}
+	cli := &mockSource{name: "cli", data: map[string]any{
+		"server": map[string]any{"port": 7070},
+	}}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, file, env, cli)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	want := map[string]any{
+		"name":   "orders",
+		"server": map[string]any{"addr": "0.0.0.0", "port": 7070},
+	}
+	got := manager.Snapshot()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Snapshot() = %v, want %v", got, want)
+	}
+
+	// The snapshot is a copy.
+	got["server"].(map[string]any)["port"] = 1
+	if again := manager.Snapshot(); !reflect.DeepEqual(again, want) {
+		t.Errorf("Snapshot() after modifying a copy = %v, want %v", again, want)
+	}
+}
+
+func TestManager_Origins(t *testing.T) {
+	type DBConfig struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+	type AppConfig struct {
+		Name string   `config:"name"`
+		Port int      `config:"port"`
+		DB   DBConfig `config:"db"`
+	}
+
+	file := &mockSource{name: "file", data: map[string]any{
+		"name": "orders",
+		"port": 8080,
+		"db":   map[string]any{"host": "localhost", "port": 5432},
+	}}
+	env := &mockSource{name: "env", data: map[string]any{
+		"port": 9090,
+		"db":   map[string]any{"host": "db.internal"},
+	}}
+	cli := &mockSource{name: "cli", data: map[string]any{
+		"port": 7070,
+	}}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, file, env, cli)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	want := map[string]string{
+		"name":    "file",
+		"port":    "cli",
+		"db.host": "env",
+		"db.port": "file",
+	}
+	if got := manager.Origins(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Origins() = %v, want %v", got, want)
+	}
+
+	// A leaf replacing a whole map takes over the origin of its leaves.
+	cli.mu.Lock()
+	cli.data = map[string]any{"port": 7070, "db": "postgres://db"}
+	cli.mu.Unlock()
+	type LooseConfig struct {
+		Name string `config:"name"`
+		Port int    `config:"port"`
+		DB   any    `config:"db"`
+	}
+	var loose LooseConfig
+	manager, err = config.NewManager(&loose, config.Options{}, file, env, cli)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	want = map[string]string{"name": "file", "port": "cli", "db": "cli"}
+	if got := manager.Origins(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Origins() after db replaced = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import "github.com/mitchellh/mapstructure"
+
+// NewBaselineBinder returns a Binder decoding with mapstructure's own
+// composition of the decode hooks, as Binders did before composeHooks, for
+// benchmarking against. Call ResetBindCaches before each bind to also do
+// without the per-type caches.
+func NewBaselineBinder() *Binder {
+	b := NewBinder()
+	b.decodeHook = mapstructure.ComposeDecodeHookFunc(
+		durationHookFunc(b.durationUnit),
+		mapstructure.StringToIPHookFunc(),
+		stringToURLHookFunc(),
+		stringToTimeHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		stringMapKeysHookFunc(),
+	)
+	return b
+}
+
+// ResetBindCaches empties the per-type field lookup and tagged-field
+// caches.
+func ResetBindCaches() {
+	structKeysCache.Clear()
+	taggedTypes.Clear()
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Get returns the raw value at a dotted path in the merged source data from
+// the last successful Reload, e.g. "server.addr".
+//
+// Get reads values without a struct field, which suits plugins and feature
+// code that don't warrant schema changes. Keys are matched exactly first,
+// then case-insensitively. Values are as the sources produced them: strings
+// from env and CLI, typed values from files. Maps and lists are copied
+// deeply, so callers may modify them.
+//
+// Returns false if the path doesn't exist.
+func (m *Manager) Get(path string) (any, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := lookupPath(m.merged, path)
+	if !ok {
+		return nil, false
+	}
+	return cloneValue(v), true
+}
+
+// lookupPath returns the value at a dotted path in m.
+func lookupPath(m map[string]any, path string) (any, bool) {
+	var current any = m
+	for _, key := range strings.Split(path, ".") {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = lookupKey(node, key)
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// GetString returns the value at path formatted as a string, or "" if the
+// path doesn't exist.
+func (m *Manager) GetString(path string) string {
+	v, ok := m.Get(path)
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// GetInt returns the value at path as an int, or 0 if the path doesn't exist
+// or the value isn't numeric.
+func (m *Manager) GetInt(path string) int {
+	v, _ := m.Get(path)
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case uint64:
+		return int(n)
+	case float64:
+		return int(n)
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
+
+// GetBool returns the value at path as a bool, or false if the path doesn't
+// exist or the value isn't a boolean.
+func (m *Manager) GetBool(path string) bool {
+	v, _ := m.Get(path)
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		parsed, _ := strconv.ParseBool(b)
+		return parsed
+	}
+	return false
+}
+
+// GetDuration returns the value at path as a time.Duration, or 0 if the path
+// doesn't exist or the value isn't a duration. Strings and numbers are read
+// as when binding: "5s" with time.ParseDuration, bare numbers in the
+// Manager's duration unit.
+func (m *Manager) GetDuration(path string) time.Duration {
+	v, ok := m.Get(path)
+	if !ok || v == nil {
+		return 0
+	}
+	d, err := durationHookFunc(m.binder.durationUnit)(reflect.TypeOf(v), durationType, v)
+	if err != nil {
+		return 0
+	}
+	out, _ := d.(time.Duration)
+	return out
+}
+
+// lookupKey finds key in m, falling back to a case-insensitive match.
+func lookupKey(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
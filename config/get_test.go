@@ -0,0 +1,82 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestManager_Get(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	file := &mockSource{
+		name: "file",
+		data: map[string]any{
+			"name": "app",
+			"server": map[string]any{
+				"addr":        ":8080",
+				"readTimeout": "5s",
+				"workers":     4,
+			},
+		},
+	}
+	env := &mockSource{
+		name: "env",
+		data: map[string]any{
+			"server": map[string]any{"debug": "true", "port": "9090"},
+		},
+	}
+
+	var cfg AppConfig
+	m, err := config.NewManager(&cfg, config.Options{}, file, env)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	if v, ok := m.Get("server.addr"); !ok || v != ":8080" {
+		t.Errorf("Get(server.addr) = %v, %v; want :8080, true", v, ok)
+	}
+	if v, ok := m.Get("server"); !ok {
+		t.Errorf("Get(server) = %v, %v; want nested map, true", v, ok)
+	} else {
+		v.(map[string]any)["addr"] = ":9999"
+		if got, _ := m.Get("server.addr"); got != ":8080" {
+			t.Errorf("Get(server.addr) = %v after modifying Get(server), want :8080", got)
+		}
+	}
+	if v, ok := m.Get("SERVER.ReadTimeout"); !ok || v != "5s" {
+		t.Errorf("Get(SERVER.ReadTimeout) = %v, %v; want case-insensitive match", v, ok)
+	}
+
+	for _, path := range []string{"server.missing", "missing", "name.nested", ""} {
+		if v, ok := m.Get(path); ok {
+			t.Errorf("Get(%q) = %v, true; want false", path, v)
+		}
+	}
+
+	if got := m.GetString("server.addr"); got != ":8080" {
+		t.Errorf("GetString(server.addr) = %q, want %q", got, ":8080")
+	}
+	if got := m.GetString("server.workers"); got != "4" {
+		t.Errorf("GetString(server.workers) = %q, want %q", got, "4")
+	}
+	if got := m.GetInt("server.workers"); got != 4 {
+		t.Errorf("GetInt(server.workers) = %d, want 4", got)
+	}
+	if got := m.GetInt("server.port"); got != 9090 {
+		t.Errorf("GetInt(server.port) = %d, want 9090", got)
+	}
+	if got := m.GetBool("server.debug"); !got {
+		t.Errorf("GetBool(server.debug) = %v, want true", got)
+	}
+	if got := m.GetDuration("server.readTimeout"); got != 5*time.Second {
+		t.Errorf("GetDuration(server.readTimeout) = %v, want 5s", got)
+	}
+
+	if m.GetString("missing") != "" || m.GetInt("missing") != 0 || m.GetBool("missing") || m.GetDuration("missing") != 0 {
+		t.Error("typed getters should return zero values for missing paths")
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ImmutableError is returned by Reload when the new configuration changes a
+// field tagged `immutable:"true"`. The reload is rejected and the current
+// configuration is kept.
+//
+// Mark fields the running process can't honor a change to, such as a
+// listen address or cluster ID:
+//
+//	type ServerConfig struct {
+//	    Addr string `config:"addr" immutable:"true"`
+//	}
+type ImmutableError struct {
+	// Fields lists the dotted config paths of the changed fields
+	// (e.g. "server.addr").
+	Fields []string
+}
+
+// Error implements the error interface.
+func (e *ImmutableError) Error() string {
+	return fmt.Sprintf("config: immutable fields changed: %s", strings.Join(e.Fields, ", "))
+}
+
+// immutableChanges returns the config paths of fields tagged immutable whose
+// values differ between oldV and newV, which must have the same type.
+func immutableChanges(oldV, newV reflect.Value, prefix string) []string {
+	for oldV.Kind() == reflect.Ptr {
+		if oldV.IsNil() || newV.IsNil() {
+			return nil
+		}
+		oldV, newV = oldV.Elem(), newV.Elem()
+	}
+	if oldV.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var changed []string
+	t := oldV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			changed = append(changed, immutableChanges(oldV.Field(i), newV.Field(i), prefix)...)
+			continue
+		}
+		name, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		if f.Tag.Get("immutable") == "true" {
+			if !reflect.DeepEqual(oldV.Field(i).Interface(), newV.Field(i).Interface()) {
+				changed = append(changed, path)
+			}
+			continue
+		}
+		changed = append(changed, immutableChanges(oldV.Field(i), newV.Field(i), path)...)
+	}
+	return changed
+}
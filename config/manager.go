@@ -2,9 +2,16 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // Manager orchestrates configuration loading from multiple sources,
@@ -20,12 +27,41 @@ import (
 // The configuration is updated atomically - validation failures prevent
 // any changes from taking effect. All public methods are safe for concurrent use.
 type Manager struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	workers   sync.WaitGroup // watchers, refresh and OnChange, waited for by Close
 	sources   []ConfigSource
 	config    any
+	merged    map[string]any
+	origins   map[string]string // source name by leaf path of merged
 	binder    *Binder
 	mu        sync.RWMutex
 	subs      []chan Event
 	autoWatch bool
+	coerce    bool
+	strict    bool
+	preserve  bool
+	logger    *slog.Logger
+	sync      bool
+	syncWait  time.Duration
+	paused    bool // background reloads deferred by PauseReload
+	pending   bool // a background reload was deferred while paused
+	loaded    bool
+	last      ReloadStatus
+	defaulted []string
+	targets   []any // Options.Targets
+	layers    []layer
+	cache     []map[string]any // per-source loads, with CacheSources
+}
+
+// ReloadStatus is the outcome of the most recent Reload.
+type ReloadStatus struct {
+	// Time the reload finished. Zero if Reload has never run.
+	Time time.Time
+
+	// Err is nil if the reload succeeded. After a failed reload the
+	// Manager keeps serving the previous (now possibly stale) config.
+	Err error
 }
 
 // Options configures the behavior of a Manager.
@@ -35,6 +71,138 @@ type Options struct {
 	// source and reload the configuration when changes are detected.
 	AutoReload bool
 
+	// CoerceTypes converts string values from sources like env and CLI to
+	// the kind of the struct field they bind to (bool, int, uint, float)
+	// in the merged map before binding. Without it, such values stay strings
+	// in the map and only reach typed fields through weak typing.
+	CoerceTypes bool
+
+	// StrictTypes binds without weak typing, so a typed source value with
+	// the wrong type (e.g. a YAML bool for an int field) is a decode error
+	// instead of being silently coerced. Values from sources implementing
+	// StringValued (env, CLI) are coerced to their field types before the
+	// merge, so they keep working.
+	StrictTypes bool
+
+	// Strict rejects keys in the merged sources that no config field binds
+	// to, so a typo like "prot: 8080" fails the load (with an
+	// *UnknownKeysError) instead of silently leaving the field's default.
+	// Every key from every source must then belong to the config struct,
+	// including env vars and flags the sources map into it.
+	Strict bool
+
+	// DurationUnit is the unit of bare numbers bound to Duration and
+	// time.Duration fields; zero means DefaultDurationUnit. See
+	// WithDurationUnit.
+	DurationUnit time.Duration
+
+	// RefreshInterval, when positive, makes the Manager call Reload on this
+	// interval regardless of whether sources support Watch. It's useful for
+	// sources like env or a static file edited in place, and coexists with
+	// AutoReload. Refreshing stops when the Manager is closed.
+	RefreshInterval time.Duration
+
+	// DeferInitialLoad skips the Reload that NewManager normally performs,
+	// for sources that aren't ready at construction time (e.g. a remote
+	// service still starting up). The caller is expected to call Reload
+	// later, or let AutoReload watchers drive the first load.
+	//
+	// The config struct stays zero-valued until the first successful Reload.
+	DeferInitialLoad bool
+
+	// DefaultsOnly allows NewManager without sources, binding only the
+	// config struct's `default` tags. Without it, no sources is an error
+	// (ErrNoSources), as it's more likely a mistake than intended.
+	DefaultsOnly bool
+
+	// InitialLoadAttempts is how many times NewManager tries the initial
+	// load before failing, so a remote source that's still starting up at
+	// boot doesn't bring the process down. Zero or one means a single try.
+	// Attempts stop early when the context passed to NewManagerContext is
+	// done.
+	InitialLoadAttempts int
+
+	// InitialLoadBackoff is the wait before the second attempt; it doubles
+	// after each further failure. Zero retries immediately.
+	InitialLoadBackoff time.Duration
+
+	// Checks enables startup checks of the dependencies config values refer
+	// to. A field tagged `check:"tcp"` is passed to Checks["tcp"] after the
+	// initial load, and NewManager fails with a *CheckError if it returns
+	// an error:
+	//
+	//	type DBConfig struct {
+	//	    Addr string `config:"addr" check:"tcp"`
+	//	}
+	//	config.NewManager(&cfg, config.Options{
+	//	    Checks: map[string]config.CheckFunc{"tcp": config.TCPCheck},
+	//	}, sources...)
+	//
+	// Checks have side effects (connections), so they only run when set,
+	// and not on reloads or with DeferInitialLoad.
+	Checks map[string]CheckFunc
+
+	// Validators registers custom validation rules by validate tag, for
+	// domain rules the standard ones don't cover:
+	//
+	//	type AppConfig struct {
+	//	    Version string `config:"version" validate:"semver"`
+	//	}
+	//	config.NewManager(&cfg, config.Options{
+	//	    Validators: map[string]validator.Func{"semver": isSemver},
+	//	}, sources...)
+	Validators map[string]validator.Func
+
+	// CheckTimeout bounds each check; zero means DefaultCheckTimeout.
+	CheckTimeout time.Duration
+
+	// CacheSources makes a watch event reload only the source that sent
+	// it, merging with the other sources' results from their last load
+	// instead of loading them again. It spares remote backends when an
+	// unrelated source changes. Reload (and RefreshInterval) still loads
+	// every source and refreshes the cache, so sources that can't watch
+	// are picked up there.
+	CacheSources bool
+
+	// PreserveUnset keeps the current value of fields that no source sets,
+	// instead of resetting them on every Reload. It lets code assign fields
+	// programmatically (before NewManager or between reloads) without
+	// losing them. Fields absent from the sources and still zero, such as
+	// ones added by a schema change, get their `default` tags applied.
+	PreserveUnset bool
+
+	// SyncNotify makes Reload wait, after applying a change, until every
+	// subscriber has called Ack on its Event, so that when Reload returns
+	// all components have reconfigured. Events are delivered blocking
+	// rather than dropped when a subscriber's channel is full. If
+	// subscribers don't all Ack within NotifyTimeout, Reload returns an
+	// error wrapping ErrNotifyTimeout; the new config stays in place.
+	SyncNotify bool
+
+	// NotifyTimeout bounds the wait of SyncNotify; zero means
+	// DefaultNotifyTimeout.
+	NotifyTimeout time.Duration
+
+	// Logger, if set, receives a debug record per source on every Reload
+	// ("config source loaded", with the source name and load duration) and
+	// a summary ("config loaded", with merge, bind and total durations).
+	// It helps diagnose slow remote sources at boot.
+	Logger *slog.Logger
+
+	// LogConfig logs the effective merged configuration once, after the
+	// initial load, as a debug record ("effective config") on Logger, so
+	// operators can confirm what loaded. Secrets are masked as by Redact.
+	// Nothing is logged unless Logger has debug enabled.
+	LogConfig bool
+
+	// Targets are further structs, given as pointers, bound from the same
+	// merged data as cfg on every Reload, e.g. the config structs of
+	// modules that don't share cfg's type. A Reload fails unless every
+	// target binds, and only cfg's changes are sent to subscribers.
+	// NewManager fails with the conflicts CompatibleTargets reports if a
+	// target binds a path as a different type than cfg or another target.
+	Targets []any
+
 	// Profile specifies the configuration profile to use.
 	// This field is currently unused by Manager but may be passed to sources.
 	// Deprecated: Profile should be set directly on FileSource instead.
@@ -50,13 +218,19 @@ type Options struct {
 //
 // Sources are processed in order, with later sources overriding earlier ones.
 // For example, with sources [file, env, cli], CLI flags will override both
-// environment variables and file values.
+// environment variables and file values. Nested maps are merged key by key;
+// where one source has a leaf and a later one a map (or the reverse), the
+// later source's value replaces the earlier one entirely (see MergeMaps).
 //
 // If opts.AutoReload is true, the Manager will start background goroutines
 // to watch each source for changes and automatically reload the configuration.
+// Close stops them.
 //
-// Returns an error if the initial load or validation fails. The configuration
-// is validated before being applied, so partial updates never occur.
+// Returns ErrNoSources if no sources are given (see Options.DefaultsOnly),
+// or an error if the initial load or validation fails. The configuration
+// is validated before being applied, so partial updates never occur. With
+// opts.DeferInitialLoad, no load happens here and cfg stays zero-valued until
+// the first successful Reload.
 //
 // Example:
 //
@@ -67,24 +241,126 @@ type Options struct {
 //	    &source.CLISource{},
 //	)
 func NewManager(cfg any, opts Options, sources ...ConfigSource) (*Manager, error) {
+	return NewManagerContext(context.Background(), cfg, opts, sources...)
+}
+
+// NewManagerContext is NewManager with a context bounding the initial load,
+// its retries (see Options.InitialLoadAttempts) and startup checks. The
+// context doesn't affect the Manager after it's returned.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	mgr, err := config.NewManagerContext(ctx, &cfg, config.Options{
+//	    InitialLoadAttempts: 5,
+//	    InitialLoadBackoff:  time.Second,
+//	}, sources...)
+func NewManagerContext(ctx context.Context, cfg any, opts Options, sources ...ConfigSource) (*Manager, error) {
+	if len(sources) == 0 && !opts.DefaultsOnly {
+		return nil, ErrNoSources
+	}
+	for _, target := range opts.Targets {
+		if t := reflect.TypeOf(target); t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("config: Options.Targets entries must be pointers to structs, got %T", target)
+		}
+	}
+	if len(opts.Targets) > 0 {
+		if err := CompatibleTargets(append([]any{cfg}, opts.Targets...)...); err != nil {
+			return nil, err
+		}
+	}
+
+	var binderOpts []BinderOption
+	if opts.StrictTypes {
+		binderOpts = append(binderOpts, WithStrictTypes())
+	}
+	if opts.Strict {
+		binderOpts = append(binderOpts, WithStrict())
+	}
+	if opts.DurationUnit > 0 {
+		binderOpts = append(binderOpts, WithDurationUnit(opts.DurationUnit))
+	}
+	binder := NewBinderWith(binderOpts...)
+	for tag, fn := range opts.Validators {
+		if err := binder.RegisterValidation(tag, fn); err != nil {
+			return nil, err
+		}
+	}
+
+	managerCtx, cancel := context.WithCancel(context.Background())
 	m := &Manager{
+		ctx:       managerCtx,
+		cancel:    cancel,
 		sources:   sources,
 		config:    cfg,
-		binder:    NewBinder(),
+		binder:    binder,
 		autoWatch: opts.AutoReload,
+		coerce:    opts.CoerceTypes,
+		strict:    opts.StrictTypes,
+		preserve:  opts.PreserveUnset,
+		logger:    opts.Logger,
+		sync:      opts.SyncNotify,
+		syncWait:  opts.NotifyTimeout,
+		targets:   opts.Targets,
+	}
+	if m.syncWait <= 0 {
+		m.syncWait = DefaultNotifyTimeout
+	}
+
+	if opts.CacheSources {
+		m.cache = make([]map[string]any, len(sources))
 	}
 
-	if err := m.Reload(context.Background()); err != nil {
-		return nil, err
+	if !opts.DeferInitialLoad {
+		if err := m.initialLoad(ctx, opts.InitialLoadAttempts, opts.InitialLoadBackoff); err != nil {
+			cancel()
+			return nil, err
+		}
+		if opts.LogConfig {
+			m.logEffectiveConfig()
+		}
+		if len(opts.Checks) > 0 {
+			if err := runChecks(ctx, cfg, opts.Checks, opts.CheckTimeout); err != nil {
+				cancel()
+				return nil, err
+			}
+		}
 	}
 
 	if m.autoWatch {
 		m.startWatchers()
 	}
+	if opts.RefreshInterval > 0 {
+		m.workers.Add(1)
+		go m.refresh(opts.RefreshInterval)
+	}
 
 	return m, nil
 }
 
+// initialLoad calls Reload up to attempts times, waiting backoff (doubling)
+// between failures, and returns the last error.
+func (m *Manager) initialLoad(ctx context.Context, attempts int, backoff time.Duration) error {
+	for attempt := 1; ; attempt++ {
+		err := m.Reload(ctx)
+		if err == nil || attempt >= attempts || ctx.Err() != nil {
+			return err
+		}
+		m.debug("config initial load failed, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (after %d attempts: %w)", err, attempt, ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
 // Reload loads configuration from all sources, validates it, and atomically
 // updates the configuration if validation succeeds.
 //
@@ -93,8 +369,9 @@ func NewManager(cfg any, opts Options, sources ...ConfigSource) (*Manager, error
 //  2. Merges the data (later sources override earlier ones)
 //  3. Creates a new configuration instance
 //  4. Validates the new configuration
-//  5. Atomically swaps the old configuration with the new one
-//  6. Notifies subscribers if any fields changed
+//  5. Rejects the reload if a field tagged `immutable:"true"` changed
+//  6. Atomically swaps the old configuration with the new one
+//  7. Notifies subscribers if any fields changed
 //
 // If any step fails, the current configuration remains unchanged and an error
 // is returned. This ensures the configuration is always in a valid state.
@@ -109,9 +386,32 @@ func NewManager(cfg any, opts Options, sources ...ConfigSource) (*Manager, error
 //   - Any source fails to load
 //   - The configuration fails to bind (decode error)
 //   - The configuration fails validation
+//   - An immutable field changed after the first load (*ImmutableError)
+//   - With Options.SyncNotify, subscribers didn't acknowledge the change in
+//     time (ErrNotifyTimeout); the new config is applied regardless
 func (m *Manager) Reload(ctx context.Context) error {
+	return m.reload(ctx, allSources)
+}
+
+// allSources makes reload load every source.
+const allSources = -1
+
+// reload is Reload, except that if changed is a source index and
+// CacheSources is on, only that source is loaded and the others are taken
+// from the cache of their last loads.
+func (m *Manager) reload(ctx context.Context, changed int) (err error) {
+	defer func() {
+		m.mu.Lock()
+		m.last = ReloadStatus{Time: time.Now(), Err: err}
+		m.mu.Unlock()
+	}()
+
+	start := time.Now()
+	var mergeTime time.Duration
 	merged := map[string]any{}
-	for _, src := range m.sources {
+	origins := map[string]string{}
+	layers := make([]layer, 0, len(m.sources))
+	for i, src := range m.sources {
 		// Check for cancellation before loading each source
 		select {
 		case <-ctx.Done():
@@ -119,40 +419,151 @@ func (m *Manager) Reload(ctx context.Context) error {
 		default:
 		}
 
-		vals, err := src.Load(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to load config from %s: %w", src.Name(), err)
+		vals, cached := m.cached(i, changed)
+		if !cached {
+			loadStart := time.Now()
+			vals, err = src.Load(ctx)
+			m.debug("config source loaded",
+				slog.String("source", src.Name()),
+				slog.Duration("duration", time.Since(loadStart)),
+				slog.Bool("ok", err == nil))
+			if err != nil {
+				return fmt.Errorf("failed to load config from %s: %w", src.Name(), err)
+			}
+			m.store(i, vals)
 		}
-		mergeMaps(merged, vals)
+
+		mergeStart := time.Now()
+		if sv, ok := src.(StringValued); ok && m.strict && sv.StringValued() {
+			coerceTypes(vals, reflect.TypeOf(m.config))
+		}
+		// Merging mutates nested maps, so Explain gets its own copy.
+		layers = append(layers, layer{name: src.Name(), data: cloneMap(vals)})
+		mergeMapsWithOrigin(merged, vals, origins, src.Name(), "")
+		mergeTime += time.Since(mergeStart)
+	}
+
+	mergeStart := time.Now()
+	if m.coerce {
+		coerceTypes(merged, reflect.TypeOf(m.config))
 	}
+	mergeTime += time.Since(mergeStart)
+
+	// Keep the sources of this attempt, even if binding fails, so Explain
+	// can show what was rejected.
+	m.mu.Lock()
+	m.layers = layers
+	m.mu.Unlock()
 
 	// Create new instance of same type as m.config
 	newCfg := reflect.New(reflect.TypeOf(m.config).Elem()).Interface()
 
 	// Bind + validate on temporary
-	if err := m.binder.Bind(merged, newCfg); err != nil {
+	bindStart := time.Now()
+	var defaulted []string
+	if m.preserve {
+		m.mu.RLock()
+		defaulted, err = m.binder.bind(merged, newCfg, &previous{cfg: m.config, defaulted: m.defaulted})
+		m.mu.RUnlock()
+	} else {
+		defaulted, err = m.binder.bind(merged, newCfg, nil)
+	}
+	if err != nil {
+		if hint := m.requiredHint(err); hint != "" {
+			return fmt.Errorf("failed to bind config: %w; %s", err, hint)
+		}
 		return fmt.Errorf("failed to bind config: %w", err)
 	}
+	targets := make([]any, len(m.targets))
+	for i, target := range m.targets {
+		targets[i] = reflect.New(reflect.TypeOf(target).Elem()).Interface()
+		if _, err := m.binder.bind(merged, targets[i], nil); err != nil {
+			return fmt.Errorf("failed to bind config into %T: %w", target, err)
+		}
+	}
+	m.debug("config loaded",
+		slog.Int("sources", len(m.sources)),
+		slog.Duration("merge", mergeTime),
+		slog.Duration("bind", time.Since(bindStart)),
+		slog.Duration("total", time.Since(start)))
 
 	// Lock and atomically replace on success
 	m.mu.Lock()
 
+	// Immutable fields are set by the first load and fixed after that
+	if m.loaded {
+		if changed := immutableChanges(reflect.ValueOf(m.config), reflect.ValueOf(newCfg), ""); len(changed) > 0 {
+			m.mu.Unlock()
+			return &ImmutableError{Fields: changed}
+		}
+	}
+
 	// Create a copy of old config for comparison
 	oldCfg := reflect.New(reflect.TypeOf(m.config).Elem()).Interface()
 	reflect.ValueOf(oldCfg).Elem().Set(reflect.ValueOf(m.config).Elem())
 
 	// Copy values from newCfg into m.config (updates the user's struct in place)
 	reflect.ValueOf(m.config).Elem().Set(reflect.ValueOf(newCfg).Elem())
+	for i, target := range m.targets {
+		reflect.ValueOf(target).Elem().Set(reflect.ValueOf(targets[i]).Elem())
+	}
+	m.merged = merged
+	m.origins = origins
+	m.defaulted = defaulted
+	m.loaded = true
 
 	m.mu.Unlock()
 
 	if !reflect.DeepEqual(oldCfg, newCfg) {
 		diffEvent := diffEvent(oldCfg, newCfg)
+		if m.sync {
+			return m.notifySync(ctx, diffEvent)
+		}
 		m.notify(diffEvent)
 	}
 	return nil
 }
 
+// LastReload returns the outcome of the most recent Reload, whether called
+// directly or by AutoReload or RefreshInterval. A failed background reload
+// leaves the previous config in place; health checks can use this to report
+// that the running config is stale.
+func (m *Manager) LastReload() ReloadStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+// Defaulted returns the dotted config paths that the last successful Reload
+// filled from `default` tags because no source set them, for logging at
+// startup:
+//
+//	logger.Info("using defaults", "fields", mgr.Defaulted())
+func (m *Manager) Defaulted() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.defaulted...)
+}
+
+// logEffectiveConfig logs the redacted merged config at debug level.
+func (m *Manager) logEffectiveConfig() {
+	if m.logger == nil || !m.logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	m.mu.RLock()
+	redacted := Redact(m.merged, m.config)
+	m.mu.RUnlock()
+	m.debug("effective config", slog.Any("config", redacted))
+}
+
+// debug logs to the optional logger.
+func (m *Manager) debug(msg string, attrs ...slog.Attr) {
+	if m.logger == nil {
+		return
+	}
+	m.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+}
+
 // Subscribe registers a channel to receive configuration change events.
 //
 // When the configuration is reloaded and changes are detected, an Event
@@ -171,6 +582,9 @@ func (m *Manager) Reload(ctx context.Context) error {
 //	    }
 //	}()
 //
+// With Options.SyncNotify, events are sent blocking instead, and every
+// subscriber must call Ack on each Event it receives.
+//
 // Subscribe is safe to call concurrently. The channel is never closed by
 // the Manager, so callers are responsible for lifecycle management.
 //
@@ -182,6 +596,70 @@ func (m *Manager) Subscribe(ch chan Event) {
 	m.subs = append(m.subs, ch)
 }
 
+// unsubscribe stops delivering events to ch.
+func (m *Manager) unsubscribe(ch chan Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i := slices.Index(m.subs, ch); i >= 0 {
+		m.subs = slices.Delete(m.subs, i, i+1)
+	}
+}
+
+// OnChange calls fn with the old and new configuration after each Reload
+// that changes it, sparing subscribers the type assertions on Event. T is
+// the struct type passed to NewManager:
+//
+//	config.OnChange(mgr, func(old, new config.Root) {
+//	    if old.Logging.Level != new.Logging.Level {
+//	        setLevel(new.Logging.Level)
+//	    }
+//	})
+//
+// fn runs on its own goroutine, one change at a time, until the Manager is
+// closed, which also unsubscribes it. Events whose configs aren't T or *T are skipped.
+//
+// By default changes are delivered like Subscribe's: up to 16 wait while
+// fn is busy, and any arriving beyond that are dropped. With
+// Options.SyncNotify none are dropped: Reload waits for fn to return,
+// which acknowledges the Event, and fails with ErrNotifyTimeout if it
+// doesn't within NotifyTimeout.
+func OnChange[T any](m *Manager, fn func(old, new T)) {
+	ch := make(chan Event, 16)
+	m.Subscribe(ch)
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		defer m.unsubscribe(ch)
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case evt := <-ch:
+				oldCfg, ok1 := typedConfig[T](evt.OldConfig)
+				newCfg, ok2 := typedConfig[T](evt.NewConfig)
+				if ok1 && ok2 {
+					fn(oldCfg, newCfg)
+				}
+				evt.Ack()
+			}
+		}
+	}()
+}
+
+// typedConfig returns v as a T, dereferencing a *T.
+func typedConfig[T any](v any) (T, bool) {
+	switch c := v.(type) {
+	case *T:
+		if c != nil {
+			return *c, true
+		}
+	case T:
+		return c, true
+	}
+	var zero T
+	return zero, false
+}
+
 func (m *Manager) notify(evt Event) {
 	m.mu.RLock()
 	subs := append([]chan Event(nil), m.subs...)
@@ -194,28 +672,185 @@ func (m *Manager) notify(evt Event) {
 	}
 }
 
+// ErrNoSources is returned by NewManager when it's given no sources and
+// Options.DefaultsOnly isn't set.
+var ErrNoSources = errors.New("config: no sources given; pass at least one ConfigSource, or set Options.DefaultsOnly to bind only defaults")
+
+// DefaultNotifyTimeout bounds the wait for subscribers with
+// Options.SyncNotify when NotifyTimeout is zero.
+const DefaultNotifyTimeout = 5 * time.Second
+
+// ErrNotifyTimeout is wrapped by the error Reload returns when, with
+// Options.SyncNotify, subscribers don't acknowledge a change in time.
+var ErrNotifyTimeout = errors.New("config: subscribers did not acknowledge the change")
+
+// notifySync delivers evt to every subscriber and waits for their Acks, up
+// to the notify timeout or until ctx is done.
+func (m *Manager) notifySync(ctx context.Context, evt Event) error {
+	m.mu.RLock()
+	subs := append([]chan Event(nil), m.subs...)
+	m.mu.RUnlock()
+
+	if len(subs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.syncWait)
+	defer cancel()
+
+	var pending atomic.Int32
+	pending.Store(int32(len(subs)))
+	acked := make(chan struct{})
+	for _, ch := range subs {
+		var once sync.Once
+		e := evt
+		e.ack = func() {
+			once.Do(func() {
+				if pending.Add(-1) == 0 {
+					close(acked)
+				}
+			})
+		}
+		select {
+		case ch <- e:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: delivery: %w", ErrNotifyTimeout, ctx.Err())
+		}
+	}
+
+	select {
+	case <-acked:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrNotifyTimeout, ctx.Err())
+	}
+}
+
+// Close stops the source watchers and background refreshing started by
+// NewManager, and waits for them to exit; each source's Watch must return
+// once its context is done. It doesn't change the current configuration.
+// Close is safe to call more than once.
+func (m *Manager) Close() error {
+	m.cancel()
+	m.workers.Wait()
+	return nil
+}
+
+// PauseReload stops source watchers and RefreshInterval from reloading,
+// freezing the configuration, e.g. for a maintenance window. Changes they
+// report while paused aren't lost: ResumeReload applies them. Explicit
+// calls to Reload still take effect.
+func (m *Manager) PauseReload() {
+	m.mu.Lock()
+	m.paused = true
+	m.mu.Unlock()
+}
+
+// ResumeReload undoes PauseReload. If a watcher or refresh wanted to reload
+// while paused, it reloads every source once, now, and returns the result.
+func (m *Manager) ResumeReload() error {
+	m.mu.Lock()
+	pending := m.paused && m.pending
+	m.paused, m.pending = false, false
+	m.mu.Unlock()
+
+	if !pending {
+		return nil
+	}
+	return m.reload(m.ctx, allSources)
+}
+
+// ReloadPaused reports whether background reloads are paused.
+func (m *Manager) ReloadPaused() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.paused
+}
+
+// deferReload reports whether background reloads are paused, recording
+// that one is due for ResumeReload if so.
+func (m *Manager) deferReload() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.paused {
+		m.pending = true
+	}
+	return m.paused
+}
+
+// refresh calls Reload every interval until the Manager is closed.
+func (m *Manager) refresh(interval time.Duration) {
+	defer m.workers.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			if m.deferReload() {
+				continue
+			}
+			// A failed refresh keeps the current config; the next tick retries.
+			_ = m.Reload(m.ctx)
+		}
+	}
+}
+
+// cached returns a copy of source i's last load if CacheSources is on and
+// the reload was triggered by another source.
+func (m *Manager) cached(i, changed int) (map[string]any, bool) {
+	if m.cache == nil || changed == allSources || i == changed {
+		return nil, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.cache[i] == nil {
+		return nil, false // never loaded: fall back to loading it
+	}
+	return cloneMap(m.cache[i]), true
+}
+
+// store caches a copy of source i's load, if CacheSources is on.
+func (m *Manager) store(i int, vals map[string]any) {
+	if m.cache == nil {
+		return
+	}
+	m.mu.Lock()
+	m.cache[i] = cloneMap(vals)
+	m.mu.Unlock()
+}
+
 func (m *Manager) startWatchers() {
-	for _, s := range m.sources {
+	for i, s := range m.sources {
 		src := s // Capture loop variable
 		ch := make(chan Event)
+		m.workers.Add(1)
 		go func() {
-			// Create a background context for watching
-			// TODO: Consider adding a context field to Manager for controlled shutdown
-			ctx := context.Background()
+			defer m.workers.Done()
 
-			if err := src.Watch(ctx, ch); err != nil {
-				// Watch returned an error or doesn't support watching
-				return
-			}
+			// Watch may block for the lifetime of the watch (sending events
+			// as they happen) or return immediately if unsupported, so it
+			// runs on its own goroutine while this one drains events.
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_ = src.Watch(m.ctx, ch)
+			}()
 
 			for {
 				select {
-				case <-ctx.Done():
+				case <-done:
+					// Watch returned: closed, failed, or doesn't support watching
 					return
 				case <-ch:
-					// Reload with background context
+					// Once closed, keep draining so Watch isn't blocked on a
+					// send, but don't reload.
+					if m.ctx.Err() != nil || m.deferReload() {
+						continue
+					}
 					// Errors are intentionally ignored as they're logged by subscribers
-					_ = m.Reload(context.Background())
+					_ = m.reload(m.ctx, i)
 				}
 			}
 		}()
@@ -3,10 +3,15 @@ package config_test
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-playground/validator/v10"
+
 	"github.com/skekre98/genever/config"
 )
 
@@ -139,6 +144,43 @@ func TestNewManager_ValidationError(t *testing.T) {
 	}
 }
 
+func TestNewManager_DeferInitialLoad(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name" validate:"required"`
+		Port int    `config:"port" validate:"required,min=1,max=65535"`
+	}
+
+	// The source isn't ready yet: loading now would fail.
+	source := &mockSource{
+		name:   "remote",
+		errVal: errors.New("connection refused"),
+	}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{DeferInitialLoad: true}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v, want nil with DeferInitialLoad", err)
+	}
+
+	if cfg != (AppConfig{}) {
+		t.Errorf("config should be zero-valued before first Reload, got %+v", cfg)
+	}
+
+	// The source comes up later.
+	source.mu.Lock()
+	source.errVal = nil
+	source.data = map[string]any{"name": "late-app", "port": 8080}
+	source.mu.Unlock()
+
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if cfg.Name != "late-app" || cfg.Port != 8080 {
+		t.Errorf("config after Reload = %+v, want Name=late-app Port=8080", cfg)
+	}
+}
+
 func TestManager_Reload(t *testing.T) {
 	type AppConfig struct {
 		Name string `config:"name" validate:"required"`
@@ -598,3 +640,869 @@ func TestManager_Reload_ConcurrentSafety(t *testing.T) {
 
 	t.Logf("Final config after concurrent reloads: Name=%s, Counter=%d", cfg.Name, cfg.Counter)
 }
+
+// stringSource is a mockSource whose values are strings, like env and CLI.
+type stringSource struct {
+	mockSource
+}
+
+func (s *stringSource) StringValued() bool { return true }
+
+func TestManager_StrictTypes(t *testing.T) {
+	type AppConfig struct {
+		Port  int  `config:"port"`
+		Debug bool `config:"debug"`
+	}
+
+	t.Run("typed source mismatch errors", func(t *testing.T) {
+		file := &mockSource{name: "file", data: map[string]any{"port": true}}
+
+		var cfg AppConfig
+		_, err := config.NewManager(&cfg, config.Options{StrictTypes: true}, file)
+		var bindErr *config.BindError
+		if !errors.As(err, &bindErr) || bindErr.Stage != "decode" {
+			t.Errorf("NewManager() error = %v, want decode BindError", err)
+		}
+	})
+
+	t.Run("string-valued sources are coerced", func(t *testing.T) {
+		file := &mockSource{name: "file", data: map[string]any{"port": 8080}}
+		env := &stringSource{mockSource{name: "env", data: map[string]any{"debug": "true", "port": "9090"}}}
+
+		var cfg AppConfig
+		if _, err := config.NewManager(&cfg, config.Options{StrictTypes: true}, file, env); err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		if cfg.Port != 9090 || !cfg.Debug {
+			t.Errorf("config = %+v, want Port=9090 Debug=true", cfg)
+		}
+	})
+}
+
+// countingSource counts Load calls.
+type countingSource struct {
+	mockSource
+	loads atomic.Int32
+}
+
+func (c *countingSource) Load(ctx context.Context) (map[string]any, error) {
+	c.loads.Add(1)
+	return c.mockSource.Load(ctx)
+}
+
+func TestManager_RefreshInterval(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	source := &countingSource{mockSource: mockSource{name: "env", data: map[string]any{"name": "v1"}}}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{RefreshInterval: 10 * time.Millisecond}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	events := make(chan config.Event, 10)
+	manager.Subscribe(events)
+
+	source.mu.Lock()
+	source.data = map[string]any{"name": "v2"}
+	source.mu.Unlock()
+
+	select {
+	case evt := <-events:
+		if got := evt.NewConfig.(*AppConfig).Name; got != "v2" {
+			t.Errorf("refreshed Name = %q, want %q", got, "v2")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("periodic refresh did not pick up the changed source")
+	}
+
+	// Refreshing stops after Close.
+	manager.Close()
+	time.Sleep(20 * time.Millisecond)
+	before := source.loads.Load()
+	time.Sleep(50 * time.Millisecond)
+	if after := source.loads.Load(); after != before {
+		t.Errorf("Load called %d times after Close, want 0", after-before)
+	}
+}
+
+// exitSource's Watch blocks until its context is done, then marks exited.
+type exitSource struct {
+	mockSource
+	watching sync.WaitGroup
+	exited   atomic.Bool
+}
+
+func (s *exitSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	defer s.exited.Store(true)
+	s.watching.Done()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestManager_CloseStopsWatchers(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	source := &exitSource{mockSource: mockSource{name: "remote", data: map[string]any{"name": "orders"}}}
+	source.watching.Add(1)
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{AutoReload: true}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	source.watching.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		manager.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return")
+	}
+	// Close waits for the watchers, so Watch has already returned.
+	if !source.exited.Load() {
+		t.Error("Watch still running after Close returned")
+	}
+}
+
+func TestManager_PauseReload(t *testing.T) {
+	type AppConfig struct {
+		Port int `config:"port"`
+	}
+
+	source := &triggerSource{mockSource: mockSource{name: "env", data: map[string]any{"port": 8080}}, trigger: make(chan struct{})}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{AutoReload: true}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	events := make(chan config.Event, 1)
+	manager.Subscribe(events)
+
+	manager.PauseReload()
+	if !manager.ReloadPaused() {
+		t.Fatal("ReloadPaused() = false after PauseReload")
+	}
+	source.mu.Lock()
+	source.data = map[string]any{"port": 9090}
+	source.mu.Unlock()
+	source.trigger <- struct{}{}
+
+	select {
+	case <-events:
+		t.Fatal("watch event reloaded while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := manager.GetInt("port"); got != 8080 {
+		t.Errorf("port while paused = %d, want 8080", got)
+	}
+
+	if err := manager.ResumeReload(); err != nil {
+		t.Fatalf("ResumeReload() error = %v", err)
+	}
+	select {
+	case evt := <-events:
+		if got := evt.NewConfig.(*AppConfig).Port; got != 9090 {
+			t.Errorf("port after resume = %d, want 9090", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("change deferred while paused not applied on resume")
+	}
+	if manager.ReloadPaused() {
+		t.Error("ReloadPaused() = true after ResumeReload")
+	}
+}
+
+// slowSource is a mockSource that takes delay to load.
+type slowSource struct {
+	mockSource
+	delay time.Duration
+}
+
+func (s *slowSource) Load(ctx context.Context) (map[string]any, error) {
+	time.Sleep(s.delay)
+	return s.mockSource.Load(ctx)
+}
+
+// recordHandler collects log records.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func TestManager_Logger_SourceTimings(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	fast := &slowSource{mockSource: mockSource{name: "file", data: map[string]any{"name": "a"}}}
+	slow := &slowSource{mockSource: mockSource{name: "remote", data: map[string]any{"name": "b"}}, delay: 50 * time.Millisecond}
+
+	h := &recordHandler{}
+	var cfg AppConfig
+	if _, err := config.NewManager(&cfg, config.Options{Logger: slog.New(h)}, fast, slow); err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	timings := map[string]time.Duration{}
+	var summary *slog.Record
+	for i, r := range h.records {
+		attrs := map[string]slog.Value{}
+		r.Attrs(func(a slog.Attr) bool {
+			attrs[a.Key] = a.Value
+			return true
+		})
+		switch r.Message {
+		case "config source loaded":
+			if r.Level != slog.LevelDebug {
+				t.Errorf("level = %v, want debug", r.Level)
+			}
+			timings[attrs["source"].String()] = attrs["duration"].Duration()
+		case "config loaded":
+			summary = &h.records[i]
+			for _, key := range []string{"merge", "bind", "total"} {
+				if _, ok := attrs[key]; !ok {
+					t.Errorf("summary missing %q", key)
+				}
+			}
+			if total := attrs["total"].Duration(); total < slow.delay {
+				t.Errorf("total = %v, want >= %v", total, slow.delay)
+			}
+		}
+	}
+
+	if len(timings) != 2 {
+		t.Fatalf("per-source records = %v, want file and remote", timings)
+	}
+	if timings["remote"] < slow.delay {
+		t.Errorf("remote duration = %v, want >= %v", timings["remote"], slow.delay)
+	}
+	if timings["file"] >= timings["remote"] {
+		t.Errorf("file duration %v not below remote %v", timings["file"], timings["remote"])
+	}
+	if summary == nil {
+		t.Error("no \"config loaded\" summary record")
+	}
+}
+
+func TestManager_LogConfig_RedactsSecrets(t *testing.T) {
+	type DBConfig struct {
+		URL      string `config:"url" secret:"true"`
+		Password string `config:"password"`
+		Pool     int    `config:"pool"`
+	}
+	type AppConfig struct {
+		Name string   `config:"name"`
+		DB   DBConfig `config:"db"`
+	}
+
+	source := &mockSource{name: "test", data: map[string]any{
+		"name": "orders",
+		"db":   map[string]any{"url": "postgres://u:p@db/orders", "password": "hunter2", "pool": 4},
+	}}
+
+	h := &recordHandler{}
+	var cfg AppConfig
+	if _, err := config.NewManager(&cfg, config.Options{Logger: slog.New(h), LogConfig: true}, source); err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	var logged map[string]any
+	for _, r := range h.records {
+		if r.Message != "effective config" {
+			continue
+		}
+		if r.Level != slog.LevelDebug {
+			t.Errorf("level = %v, want debug", r.Level)
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "config" {
+				logged, _ = a.Value.Any().(map[string]any)
+			}
+			return true
+		})
+	}
+	if logged == nil {
+		t.Fatal("no \"effective config\" record")
+	}
+
+	db := logged["db"].(map[string]any)
+	if db["url"] != config.Redacted || db["password"] != config.Redacted {
+		t.Errorf("secrets not redacted: %v", db)
+	}
+	if db["pool"] != 4 || logged["name"] != "orders" {
+		t.Errorf("logged config = %v, want non-secret values intact", logged)
+	}
+	if cfg.DB.Password != "hunter2" {
+		t.Errorf("Password = %q, redaction must not touch the bound config", cfg.DB.Password)
+	}
+}
+
+func TestManager_LogConfig_Off(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	h := &recordHandler{}
+	var cfg AppConfig
+	source := &mockSource{name: "test", data: map[string]any{"name": "orders"}}
+	if _, err := config.NewManager(&cfg, config.Options{Logger: slog.New(h)}, source); err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	for _, r := range h.records {
+		if r.Message == "effective config" {
+			t.Error("effective config logged without LogConfig")
+		}
+	}
+}
+
+func TestManager_Reload_ImmutableFields(t *testing.T) {
+	type ServerConfig struct {
+		Addr    string `config:"addr" immutable:"true"`
+		Timeout int    `config:"timeout"`
+	}
+	type AppConfig struct {
+		Server ServerConfig `config:"server"`
+	}
+
+	source := &mockSource{
+		name: "test",
+		data: map[string]any{"server": map[string]any{"addr": ":8080", "timeout": 5}},
+	}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	t.Run("mutable change is applied", func(t *testing.T) {
+		source.mu.Lock()
+		source.data = map[string]any{"server": map[string]any{"addr": ":8080", "timeout": 10}}
+		source.mu.Unlock()
+
+		if err := manager.Reload(context.Background()); err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		if cfg.Server.Timeout != 10 {
+			t.Errorf("Timeout = %d, want 10", cfg.Server.Timeout)
+		}
+	})
+
+	t.Run("immutable change is rejected", func(t *testing.T) {
+		source.mu.Lock()
+		source.data = map[string]any{"server": map[string]any{"addr": ":9090", "timeout": 20}}
+		source.mu.Unlock()
+
+		err := manager.Reload(context.Background())
+		var immErr *config.ImmutableError
+		if !errors.As(err, &immErr) {
+			t.Fatalf("Reload() error = %v, want ImmutableError", err)
+		}
+		if len(immErr.Fields) != 1 || immErr.Fields[0] != "server.addr" {
+			t.Errorf("Fields = %v, want [server.addr]", immErr.Fields)
+		}
+		if cfg.Server.Addr != ":8080" || cfg.Server.Timeout != 10 {
+			t.Errorf("config = %+v, want old config retained", cfg.Server)
+		}
+	})
+}
+
+func TestManager_LastReload(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	source := &mockSource{name: "test", data: map[string]any{"name": "v1"}}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if last := manager.LastReload(); last.Err != nil || last.Time.IsZero() {
+		t.Errorf("LastReload() = %+v, want successful reload", last)
+	}
+
+	source.mu.Lock()
+	source.errVal = errors.New("backend unavailable")
+	source.mu.Unlock()
+	_ = manager.Reload(context.Background())
+
+	if last := manager.LastReload(); last.Err == nil {
+		t.Error("LastReload().Err = nil after failed reload")
+	}
+	if cfg.Name != "v1" {
+		t.Errorf("Name = %q, want previous config retained", cfg.Name)
+	}
+}
+
+func TestManager_Defaulted(t *testing.T) {
+	source := &mockSource{
+		name: "file",
+		data: map[string]any{
+			"app":    map[string]any{"name": "orders", "version": "1.0.0"},
+			"server": map[string]any{"addr": ":9090"},
+		},
+	}
+
+	var cfg config.Root
+	manager, err := config.NewManager(&cfg, config.Options{}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	want := []string{"observability.metrics.path", "actuator.basePath"}
+	if got := manager.Defaulted(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Defaulted() = %v, want %v", got, want)
+	}
+	if cfg.Server.Addr != ":9090" {
+		t.Errorf("Server.Addr = %q, want source value", cfg.Server.Addr)
+	}
+}
+
+func TestManager_PreserveUnset(t *testing.T) {
+	type AppConfig struct {
+		Name    string `config:"name"`
+		Feature string `config:"feature"`
+		Retries int    `config:"retries" default:"3"`
+		Timeout string `config:"timeout" default:"30s"`
+	}
+
+	source := &mockSource{
+		name: "file",
+		data: map[string]any{"name": "orders", "retries": 5},
+	}
+
+	cfg := AppConfig{Feature: "on"} // set in code, not by any source
+	manager, err := config.NewManager(&cfg, config.Options{PreserveUnset: true}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if cfg.Feature != "on" || cfg.Retries != 5 || cfg.Timeout != "30s" {
+		t.Fatalf("after load cfg = %+v", cfg)
+	}
+
+	source.mu.Lock()
+	source.data = map[string]any{"name": "payments"}
+	source.mu.Unlock()
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	want := AppConfig{Name: "payments", Feature: "on", Retries: 5, Timeout: "30s"}
+	if cfg != want {
+		t.Errorf("after reload cfg = %+v, want %+v", cfg, want)
+	}
+	if got := manager.Defaulted(); !reflect.DeepEqual(got, []string{"timeout"}) {
+		t.Errorf("Defaulted() = %v, want [timeout]", got)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := AppConfig{Feature: "on"}
+		if _, err := config.NewManager(&cfg, config.Options{}, source); err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		if cfg.Feature != "" || cfg.Retries != 3 {
+			t.Errorf("cfg = %+v, want unset fields reset and defaulted", cfg)
+		}
+	})
+}
+
+func TestManager_PreserveUnsetReappliesNestedDefaults(t *testing.T) {
+	type DBConfig struct {
+		Host string `config:"host"`
+		Port int    `config:"port" default:"5432"`
+	}
+	type AppConfig struct {
+		Name string   `config:"name"`
+		DB   DBConfig `config:"db"`
+	}
+
+	source := &mockSource{
+		name: "file",
+		data: map[string]any{"name": "orders", "db": map[string]any{"host": "db.internal"}},
+	}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{PreserveUnset: true}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	source.mu.Lock()
+	source.data = map[string]any{"name": "orders"}
+	source.mu.Unlock()
+	for i := range 2 {
+		if err := manager.Reload(context.Background()); err != nil {
+			t.Fatalf("Reload() #%d error = %v", i+1, err)
+		}
+		if cfg.DB.Host != "db.internal" || cfg.DB.Port != 5432 {
+			t.Errorf("after reload #%d cfg.DB = %+v, want host kept and port defaulted", i+1, cfg.DB)
+		}
+		if got := manager.Defaulted(); !reflect.DeepEqual(got, []string{"db.port"}) {
+			t.Errorf("after reload #%d Defaulted() = %v, want [db.port]", i+1, got)
+		}
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+		Port int    `config:"port"`
+	}
+
+	source := &mockSource{
+		name: "file",
+		data: map[string]any{"name": "orders", "port": 8080},
+	}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	type change struct{ old, new AppConfig }
+	changes := make(chan change, 1)
+	config.OnChange(manager, func(old, new AppConfig) {
+		changes <- change{old, new}
+	})
+
+	source.mu.Lock()
+	source.data["port"] = 9090
+	source.mu.Unlock()
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	select {
+	case got := <-changes:
+		if got.old.Port != 8080 || got.new.Port != 9090 || got.new.Name != "orders" {
+			t.Errorf("OnChange got old = %+v, new = %+v", got.old, got.new)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnChange callback not called")
+	}
+}
+
+func TestOnChange_UnsubscribesOnClose(t *testing.T) {
+	type AppConfig struct {
+		Port int `config:"port"`
+	}
+
+	source := &mockSource{name: "file", data: map[string]any{"port": 8080}}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{SyncNotify: true, NotifyTimeout: 2 * time.Second}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	config.OnChange(manager, func(old, new AppConfig) {})
+	manager.Close()
+
+	source.mu.Lock()
+	source.data["port"] = 9090
+	source.mu.Unlock()
+	start := time.Now()
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Errorf("Reload() after Close error = %v, want nil", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Reload() after Close took %v waiting for a closed OnChange", elapsed)
+	}
+}
+
+func TestManager_SyncNotify(t *testing.T) {
+	type AppConfig struct {
+		Port int `config:"port"`
+	}
+
+	source := &mockSource{name: "file", data: map[string]any{"port": 8080}}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{SyncNotify: true}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	ch := make(chan config.Event)
+	manager.Subscribe(ch)
+	var applied atomic.Bool
+	go func() {
+		for evt := range ch {
+			time.Sleep(50 * time.Millisecond) // reconfigure
+			applied.Store(true)
+			evt.Ack()
+		}
+	}()
+	defer close(ch)
+
+	var onChange atomic.Bool
+	config.OnChange(manager, func(_, _ AppConfig) { onChange.Store(true) })
+
+	source.mu.Lock()
+	source.data["port"] = 9090
+	source.mu.Unlock()
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if !applied.Load() || !onChange.Load() {
+		t.Errorf("Reload() returned before subscribers acked (applied = %v, OnChange = %v)", applied.Load(), onChange.Load())
+	}
+}
+
+func TestManager_SyncNotifyTimeout(t *testing.T) {
+	type AppConfig struct {
+		Port int `config:"port"`
+	}
+
+	source := &mockSource{name: "file", data: map[string]any{"port": 8080}}
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{SyncNotify: true, NotifyTimeout: 20 * time.Millisecond}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	// Receives but never acks.
+	manager.Subscribe(make(chan config.Event, 1))
+
+	source.mu.Lock()
+	source.data["port"] = 9090
+	source.mu.Unlock()
+	err = manager.Reload(context.Background())
+	if !errors.Is(err, config.ErrNotifyTimeout) {
+		t.Fatalf("Reload() error = %v, want ErrNotifyTimeout", err)
+	}
+	if cfg.Port != 9090 {
+		t.Errorf("Port = %d, want the new config applied", cfg.Port)
+	}
+}
+
+// triggerSource sends a watch event whenever its trigger channel receives.
+type triggerSource struct {
+	mockSource
+	trigger chan struct{}
+}
+
+func (s *triggerSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.trigger:
+			ch <- config.Event{}
+		}
+	}
+}
+
+func TestManager_CacheSources(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+		Port int    `config:"port"`
+	}
+
+	remote := &countingSource{mockSource: mockSource{name: "remote", data: map[string]any{"name": "orders", "port": 8080}}}
+	env := &triggerSource{mockSource: mockSource{name: "env", data: map[string]any{}}, trigger: make(chan struct{})}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{AutoReload: true, CacheSources: true}, remote, env)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	changes := make(chan config.Event, 1)
+	manager.Subscribe(changes)
+
+	env.mu.Lock()
+	env.data = map[string]any{"port": 9090}
+	env.mu.Unlock()
+	env.trigger <- struct{}{}
+
+	select {
+	case evt := <-changes:
+		if got := evt.NewConfig.(*AppConfig); got.Name != "orders" || got.Port != 9090 {
+			t.Errorf("config = %+v, want cached remote name with env port", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no reload after env change")
+	}
+	if got := remote.loads.Load(); got != 1 {
+		t.Errorf("remote loaded %d times, want 1 (cached on env change)", got)
+	}
+
+	// An explicit Reload loads every source again.
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := remote.loads.Load(); got != 2 {
+		t.Errorf("remote loaded %d times after Reload, want 2", got)
+	}
+}
+
+// flakySource fails its first `failures` loads.
+type flakySource struct {
+	mockSource
+	failures int32
+	loads    atomic.Int32
+}
+
+func (f *flakySource) Load(ctx context.Context) (map[string]any, error) {
+	if f.loads.Add(1) <= f.failures {
+		return nil, errors.New("connection refused")
+	}
+	return f.mockSource.Load(ctx)
+}
+
+func TestNewManager_InitialLoadRetry(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	source := &flakySource{mockSource: mockSource{name: "remote", data: map[string]any{"name": "orders"}}, failures: 2}
+	var cfg AppConfig
+	_, err := config.NewManager(&cfg, config.Options{
+		InitialLoadAttempts: 3,
+		InitialLoadBackoff:  time.Millisecond,
+	}, source)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if got := source.loads.Load(); got != 3 {
+		t.Errorf("loads = %d, want 3", got)
+	}
+	if cfg.Name != "orders" {
+		t.Errorf("Name = %q, want orders", cfg.Name)
+	}
+}
+
+func TestNewManager_InitialLoadRetryExhausted(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	source := &flakySource{mockSource: mockSource{name: "remote"}, failures: 5}
+	var cfg AppConfig
+	_, err := config.NewManager(&cfg, config.Options{InitialLoadAttempts: 2}, source)
+	if err == nil {
+		t.Fatal("NewManager() succeeded, want error")
+	}
+	if got := source.loads.Load(); got != 2 {
+		t.Errorf("loads = %d, want 2", got)
+	}
+}
+
+func TestNewManagerContext_RetryStopsAtDeadline(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name"`
+	}
+
+	source := &flakySource{mockSource: mockSource{name: "remote"}, failures: 100}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var cfg AppConfig
+	start := time.Now()
+	_, err := config.NewManagerContext(ctx, &cfg, config.Options{
+		InitialLoadAttempts: 100,
+		InitialLoadBackoff:  time.Second,
+	}, source)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("NewManagerContext() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("NewManagerContext() took %v, want it to stop at the deadline", elapsed)
+	}
+}
+
+func TestManager_NoSources(t *testing.T) {
+	type AppConfig struct {
+		Addr string `config:"addr" default:":8080" validate:"required"`
+		Port int    `config:"port" default:"8080"`
+	}
+
+	var cfg AppConfig
+	if _, err := config.NewManager(&cfg, config.Options{}); !errors.Is(err, config.ErrNoSources) {
+		t.Fatalf("NewManager() without sources error = %v, want ErrNoSources", err)
+	}
+
+	manager, err := config.NewManager(&cfg, config.Options{DefaultsOnly: true})
+	if err != nil {
+		t.Fatalf("NewManager() with DefaultsOnly error = %v", err)
+	}
+	defer manager.Close()
+	if cfg.Addr != ":8080" || cfg.Port != 8080 {
+		t.Errorf("config = %+v, want defaults", cfg)
+	}
+}
+
+func TestManager_Strict(t *testing.T) {
+	type AppConfig struct {
+		Port int `config:"port" default:"8080"`
+	}
+	source := &mockSource{name: "file", data: map[string]any{"prot": 9090}}
+
+	var cfg AppConfig
+	_, err := config.NewManager(&cfg, config.Options{Strict: true}, source)
+	var unknown *config.UnknownKeysError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("NewManager() error = %v, want UnknownKeysError", err)
+	}
+
+	manager, err := config.NewManager(&cfg, config.Options{}, source)
+	if err != nil {
+		t.Fatalf("lenient NewManager() error = %v", err)
+	}
+	defer manager.Close()
+}
+
+func TestManager_Validators(t *testing.T) {
+	type AppConfig struct {
+		Version string `config:"version" validate:"semver"`
+	}
+	validators := map[string]validator.Func{"semver": isSemver}
+
+	var cfg AppConfig
+	manager, err := config.NewManager(&cfg, config.Options{Validators: validators},
+		&mockSource{name: "file", data: map[string]any{"version": "2.0.0"}})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer manager.Close()
+
+	_, err = config.NewManager(&cfg, config.Options{Validators: validators},
+		&mockSource{name: "file", data: map[string]any{"version": "two"}})
+	var bindErr *config.BindError
+	if !errors.As(err, &bindErr) || bindErr.Stage != "validate" {
+		t.Errorf("NewManager() error = %v, want validate BindError", err)
+	}
+
+	_, err = config.NewManager(&cfg, config.Options{Validators: map[string]validator.Func{"": isSemver}},
+		&mockSource{name: "file", data: map[string]any{"version": "2.0.0"}})
+	if err == nil {
+		t.Error("NewManager() with an empty validator tag: expected error")
+	}
+}
@@ -1,5 +1,18 @@
 package config
 
+// MergeMaps deep-merges src into dst: nested maps present in both are
+// merged recursively, and any other value in src replaces the one in dst.
+// Sources that combine several documents (e.g. profile chains) use it to
+// merge the way the Manager merges sources.
+//
+// When a key holds a leaf on one side and a map on the other, src's shape
+// wins entirely: a map replaces a leaf and a leaf replaces a whole map.
+// With sources [env, cli], GENEVER_DB=x and --db.host=y give
+// {db: {host: y}}, and GENEVER_DB_HOST=y with --db=x gives {db: x}.
+func MergeMaps(dst, src map[string]any) {
+	mergeMaps(dst, src)
+}
+
 func mergeMaps(dst, src map[string]any) {
 	for k, v := range src {
 		if mv, ok := v.(map[string]any); ok {
@@ -11,3 +24,48 @@ func mergeMaps(dst, src map[string]any) {
 		dst[k] = v
 	}
 }
+
+// mergeMapsWithOrigin is mergeMaps, also recording in origins, keyed by
+// dotted path, that source name set each leaf it merges. Leaves it replaces
+// along with their map, or that a map replaces, lose their origin.
+func mergeMapsWithOrigin(dst, src map[string]any, origins map[string]string, name, prefix string) {
+	for k, v := range src {
+		path := joinPath(prefix, k)
+		if mv, ok := v.(map[string]any); ok {
+			if existing, ok := dst[k].(map[string]any); ok {
+				mergeMapsWithOrigin(existing, mv, origins, name, path)
+				continue
+			}
+		}
+		clearOrigins(origins, dst[k], path)
+		dst[k] = v
+		setOrigins(origins, v, name, path)
+	}
+}
+
+// clearOrigins removes from origins the leaves of old, the value being
+// replaced at path. Origins only holds leaves of the merged map, so walking
+// old finds them all without scanning origins.
+func clearOrigins(origins map[string]string, old any, path string) {
+	m, ok := old.(map[string]any)
+	if !ok {
+		delete(origins, path)
+		return
+	}
+	for k, nested := range m {
+		clearOrigins(origins, nested, joinPath(path, k))
+	}
+}
+
+// setOrigins records name as the origin of v at path, or of each leaf of
+// v if it's a map.
+func setOrigins(origins map[string]string, v any, name, path string) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		origins[path] = name
+		return
+	}
+	for k, nested := range m {
+		setOrigins(origins, nested, name, joinPath(path, k))
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OrderedMap is a string-keyed map that remembers key insertion order.
+//
+// Decoding YAML into map[string]any loses the order keys were written in.
+// OrderedMap keeps it: unmarshaling from YAML records keys as authored, and
+// MarshalYAML re-emits them in the same order, so dumped or round-tripped
+// config diffs cleanly against its source. Nested mappings decode to nested
+// *OrderedMap values.
+//
+// The zero value is ready to use.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// Set stores v under k. New keys are appended; existing keys keep their
+// position.
+func (o *OrderedMap) Set(k string, v any) {
+	if o.values == nil {
+		o.values = make(map[string]any)
+	}
+	if _, exists := o.values[k]; !exists {
+		o.keys = append(o.keys, k)
+	}
+	o.values[k] = v
+}
+
+// Get returns the value stored under k.
+func (o *OrderedMap) Get(k string) (any, bool) {
+	v, ok := o.values[k]
+	return v, ok
+}
+
+// Keys returns the keys in insertion order.
+func (o *OrderedMap) Keys() []string {
+	return append([]string(nil), o.keys...)
+}
+
+// Map converts o to plain nested maps, as the rest of the config pipeline
+// expects.
+func (o *OrderedMap) Map() map[string]any {
+	out := make(map[string]any, len(o.keys))
+	for _, k := range o.keys {
+		out[k] = plainValue(o.values[k])
+	}
+	return out
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, preserving key order.
+func (o *OrderedMap) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var key string
+		if err := node.Content[i].Decode(&key); err != nil {
+			return err
+		}
+		val, err := orderedValue(node.Content[i+1])
+		if err != nil {
+			return err
+		}
+		o.Set(key, val)
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting keys in insertion order.
+func (o *OrderedMap) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, k := range o.keys {
+		var key, val yaml.Node
+		if err := key.Encode(k); err != nil {
+			return nil, err
+		}
+		if err := val.Encode(o.values[k]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &key, &val)
+	}
+	return node, nil
+}
+
+func orderedValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		m := &OrderedMap{}
+		if err := m.UnmarshalYAML(node); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		list := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			v, err := orderedValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case yaml.AliasNode:
+		return orderedValue(node.Alias)
+	default:
+		var v any
+		err := node.Decode(&v)
+		return v, err
+	}
+}
+
+func plainValue(v any) any {
+	switch val := v.(type) {
+	case *OrderedMap:
+		return val.Map()
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = plainValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
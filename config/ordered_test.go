@@ -0,0 +1,74 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestOrderedMap_RoundTrip(t *testing.T) {
+	input := `zeta: 1
+server:
+  port: 8080
+  addr: localhost
+alpha:
+  - name: b
+    weight: 2
+  - name: a
+    weight: 1
+middle: true
+`
+	var m OrderedMap
+	if err := yaml.Unmarshal([]byte(input), &m); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got, want := m.Keys(), []string{"zeta", "server", "alpha", "middle"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	server, _ := m.Get("server")
+	if got, want := server.(*OrderedMap).Keys(), []string{"port", "addr"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("nested Keys() = %v, want %v", got, want)
+	}
+
+	var out strings.Builder
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&m); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if out.String() != input {
+		t.Errorf("Encode() =\n%s\nwant\n%s", out.String(), input)
+	}
+}
+
+func TestOrderedMap_Map(t *testing.T) {
+	var m OrderedMap
+	if err := yaml.Unmarshal([]byte("b:\n  c: 1\na: [x, {y: 2}]\n"), &m); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := map[string]any{
+		"b": map[string]any{"c": 1},
+		"a": []any{"x", map[string]any{"y": 2}},
+	}
+	if got := m.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %#v, want %#v", got, want)
+	}
+}
+
+func TestOrderedMap_Set(t *testing.T) {
+	var m OrderedMap
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+
+	if got, want := m.Keys(), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if v, _ := m.Get("b"); v != 3 {
+		t.Errorf("Get(b) = %v, want 3", v)
+	}
+}
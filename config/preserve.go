@@ -0,0 +1,74 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// previous is the config a Reload replaces, for Options.PreserveUnset.
+type previous struct {
+	cfg any
+
+	// defaulted are the paths of cfg filled from default tags, which are
+	// applied afresh rather than preserved.
+	defaulted []string
+}
+
+// preserveUnset copies into dst the non-zero fields of prev whose config
+// keys are absent from source, recursing into nested structs whether or
+// not source sets them. Fields absent from source and zero in prev (e.g.
+// added by a schema change) or filled from defaults are left zero, so
+// defaults fill them again.
+func preserveUnset(dst, prev reflect.Value, source map[string]any, prefix string, defaulted map[string]bool) {
+	for dst.Kind() == reflect.Ptr {
+		if dst.IsNil() || prev.IsNil() {
+			return
+		}
+		dst, prev = dst.Elem(), prev.Elem()
+	}
+	if dst.Kind() != reflect.Struct {
+		return
+	}
+
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			preserveUnset(dst.Field(i), prev.Field(i), source, prefix, defaulted)
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		path := joinPath(prefix, key)
+		val, present := sourceValue(source, key)
+		if !present {
+			if _, hasDefault := f.Tag.Lookup("default"); !hasDefault && IsNested(f.Type) {
+				preserveUnset(dst.Field(i), prev.Field(i), nil, path, defaulted)
+				continue
+			}
+			if !prev.Field(i).IsZero() && !defaulted[path] {
+				dst.Field(i).Set(prev.Field(i))
+			}
+			continue
+		}
+		if nested, ok := val.(map[string]any); ok {
+			preserveUnset(dst.Field(i), prev.Field(i), nested, path, defaulted)
+		}
+	}
+}
+
+// sourceValue looks up key in m the way the Binder matches it to a field:
+// exactly, or else case-insensitively.
+func sourceValue(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Redacted replaces secret values in the output of Redact.
+const Redacted = "******"
+
+// secretKeyWords mark a key as secret when its lowercased name contains
+// one of them.
+var secretKeyWords = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "privatekey", "private_key", "credential"}
+
+// Redact returns a deep copy of the config map m with secret values
+// replaced by Redacted, for logging or display. A value is secret if its
+// key looks like one (password, secret, token, apiKey, ...) or if schema,
+// the config struct, tags the field it binds to with `secret:"true"`.
+// schema may be nil.
+func Redact(m map[string]any, schema any) map[string]any {
+	var t reflect.Type
+	if schema != nil {
+		var ok bool
+		if t, ok = schema.(reflect.Type); !ok {
+			t = reflect.TypeOf(schema)
+		}
+	}
+	return redactMap(m, t)
+}
+
+func redactMap(m map[string]any, t reflect.Type) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		field, inSchema := schemaField(t, k)
+		if secretKey(k) || (inSchema && field.Tag.Get("secret") == "true") {
+			out[k] = Redacted
+			continue
+		}
+		var nested reflect.Type
+		if inSchema {
+			nested = field.Type
+		} else if t != nil && indirectType(t).Kind() == reflect.Map {
+			nested = indirectType(t).Elem()
+		}
+		out[k] = redactValue(v, nested)
+	}
+	return out
+}
+
+// redactValue redacts the maps in v, including those inside lists, such
+// as a list of credentials. t is the schema type v binds to, or nil.
+func redactValue(v any, t reflect.Type) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactMap(v, t)
+	case []any:
+		var elem reflect.Type
+		if t != nil {
+			if t = indirectType(t); t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+				elem = t.Elem()
+			}
+		}
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = redactValue(e, elem)
+		}
+		return out
+	}
+	return v
+}
+
+// schemaField finds the field of struct type t (if any) that key binds to.
+func schemaField(t reflect.Type, key string) (reflect.StructField, bool) {
+	if t == nil {
+		return reflect.StructField{}, false
+	}
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	return lookupField(t, key)
+}
+
+func secretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, w := range secretKeyWords {
+		if strings.Contains(key, w) {
+			return true
+		}
+	}
+	return false
+}
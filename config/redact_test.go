@@ -0,0 +1,50 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestRedact(t *testing.T) {
+	type Upstream struct {
+		Name string `config:"name"`
+		Key  string `config:"key" secret:"true"`
+	}
+	type Config struct {
+		Name      string              `config:"name"`
+		Upstreams []Upstream          `config:"upstreams"`
+		Tenants   map[string]Upstream `config:"tenants"`
+	}
+	m := map[string]any{
+		"name": "orders",
+		"upstreams": []any{
+			map[string]any{"name": "billing", "key": "k-123"},
+			map[string]any{"name": "stock", "password": "hunter2"},
+		},
+		"tenants": map[string]any{
+			"acme": map[string]any{"name": "acme", "key": "k-456"},
+		},
+		"credentials": []any{map[string]any{"user": "ops", "token": "t-789"}},
+	}
+
+	got := config.Redact(m, Config{})
+	want := map[string]any{
+		"name": "orders",
+		"upstreams": []any{
+			map[string]any{"name": "billing", "key": config.Redacted},
+			map[string]any{"name": "stock", "password": config.Redacted},
+		},
+		"tenants": map[string]any{
+			"acme": map[string]any{"name": "acme", "key": config.Redacted},
+		},
+		"credentials": config.Redacted,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Redact() =\n%v\nwant\n%v", got, want)
+	}
+	if m["upstreams"].([]any)[0].(map[string]any)["key"] != "k-123" {
+		t.Error("Redact() modified its input")
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// EnvNamer is implemented by sources that can name the variable an
+// operator would set to provide a config path, such as EnvSource
+// (server.addr -> GENEVER_SERVER_ADDR). The Manager uses it to suggest
+// variables for missing required fields.
+type EnvNamer interface {
+	EnvVarName(path []string) string
+}
+
+// MissingRequired returns the dotted config paths (e.g. "server.addr") of
+// fields that failed a `required` validation in err, a Bind error for a
+// value of schema's type. Fields in list or map elements keep their index
+// ("ports[0].name"). It returns nil if err has no such failures.
+func MissingRequired(err error, schema any) []string {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	t, ok := schema.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(schema)
+	}
+
+	var paths []string
+	for _, fe := range verrs {
+		if fe.Tag() != "required" {
+			continue
+		}
+		if path, ok := configPath(t, fe.StructNamespace()); ok {
+			paths = append(paths, strings.Join(path, "."))
+		}
+	}
+	return paths
+}
+
+// configPath converts a validator namespace of Go field names
+// ("AppConfig.Server.Addr") into config keys under t.
+func configPath(t reflect.Type, namespace string) ([]string, bool) {
+	path, _, ok := namespaceField(t, namespace)
+	return path, ok
+}
+
+// namespaceField is configPath, also returning the struct field the
+// namespace ends at. Indexes stay on their key ("Ports[0]" -> "ports[0]").
+func namespaceField(t reflect.Type, namespace string) ([]string, reflect.StructField, bool) {
+	names := strings.Split(namespace, ".")
+	if len(names) < 2 {
+		return nil, reflect.StructField{}, false
+	}
+	var (
+		path  []string
+		field reflect.StructField
+	)
+	for _, name := range names[1:] { // the first element is the type name
+		name, index, indexed := strings.Cut(name, "[")
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return nil, reflect.StructField{}, false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return nil, reflect.StructField{}, false
+		}
+		field, t = f, f.Type
+		if Squashed(f) {
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			return nil, reflect.StructField{}, false
+		}
+		if indexed {
+			key += "[" + index
+			for range strings.Count(index, "[") + 1 {
+				if t = indirectType(t); t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map {
+					return nil, reflect.StructField{}, false
+				}
+				t = t.Elem()
+			}
+		}
+		path = append(path, key)
+	}
+	return path, field, true
+}
+
+// requiredHint suggests variables to set for the required fields missing
+// in err, or "" if there are none or no source can name them. Fields in
+// list elements ("ports[0].name") are left out: no variable sets a single
+// element.
+func (m *Manager) requiredHint(err error) string {
+	var names []string
+	for _, path := range MissingRequired(err, m.config) {
+		if strings.Contains(path, "[") {
+			continue
+		}
+		for _, src := range m.sources {
+			if namer, ok := src.(EnvNamer); ok {
+				names = append(names, namer.EnvVarName(strings.Split(path, "."))+" ("+path+")")
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "missing required values, set " + strings.Join(names, ", ")
+}
@@ -9,7 +9,7 @@ type AppInfo struct {
 
 type MetricsConfig struct {
 	Enabled bool   `config:"enabled"`
-	Path    string `config:"path"`
+	Path    string `config:"path" default:"/actuator/metrics"`
 }
 
 type ObservabilityConfig struct {
@@ -17,14 +17,78 @@ type ObservabilityConfig struct {
 }
 
 type ActuatorConfig struct {
-	BasePath string `config:"basePath"`
+	BasePath string `config:"basePath" default:"/actuator"`
+}
+
+// TLSConfig enables HTTPS. The certificate files are re-read when they
+// change, so renewed certificates apply without a restart.
+type TLSConfig struct {
+	Enabled  bool   `config:"enabled"`
+	CertFile string `config:"certFile" validate:"required_if=Enabled true"`
+	KeyFile  string `config:"keyFile" validate:"required_if=Enabled true"`
 }
 
 type ServerConfig struct {
-	Addr         string        `config:"addr" validate:"required"`
+	Addr         string        `config:"addr" default:":8080" validate:"required" immutable:"true"`
 	ReadTimeout  time.Duration `config:"readTimeout"`
 	WriteTimeout time.Duration `config:"writeTimeout"`
 	IdleTimeout  time.Duration `config:"idleTimeout"`
+	Mode         string        `config:"mode" validate:"omitempty,oneof=debug release test"`
+	TLS          TLSConfig     `config:"tls"`
+
+	// RequestTimeout bounds every route's handlers; zero means no limit.
+	RequestTimeout time.Duration `config:"requestTimeout"`
+	// RouteTimeouts overrides RequestTimeout for the routes it names, by
+	// method and pattern ("GET /reports/:id") or by pattern alone.
+	RouteTimeouts map[string]time.Duration `config:"routeTimeouts"`
+
+	// CORS sets the CORS policy of the routes under each path prefix
+	// ("/public", "/internal"; "/" for all routes). A request uses the
+	// policy of the longest prefix matching its path; routes outside every
+	// prefix send no CORS headers, so browsers deny cross-origin calls.
+	CORS map[string]CORSPolicy `config:"cors"`
+}
+
+// CORSPolicy is the cross-origin resource sharing policy of a group of
+// routes.
+type CORSPolicy struct {
+	// AllowOrigins lists the origins allowed to call the routes, e.g.
+	// "https://admin.example.com"; "*" allows any origin.
+	AllowOrigins []string `config:"allowOrigins"`
+	// AllowMethods answers preflights; empty allows GET, HEAD, POST, PUT,
+	// PATCH and DELETE.
+	AllowMethods []string `config:"allowMethods"`
+	// AllowHeaders answers preflights; empty allows the headers the
+	// preflight asks for.
+	AllowHeaders []string `config:"allowHeaders"`
+	// ExposeHeaders lists response headers scripts may read.
+	ExposeHeaders []string `config:"exposeHeaders"`
+	// AllowCredentials lets requests carry cookies and auth headers. It
+	// requires listing the origins: with "*" the web module refuses the
+	// policy, as any site could then make credentialed requests.
+	AllowCredentials bool `config:"allowCredentials"`
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration `config:"maxAge"`
+}
+
+// FeaturesConfig holds the app's feature flags.
+type FeaturesConfig struct {
+	// Flags enables or disables features by name.
+	Flags map[string]bool `config:"flags"`
+	// Remote refreshes flags from a flag service, overriding Flags.
+	Remote RemoteFlagsConfig `config:"remote"`
+}
+
+// RemoteFlagsConfig points the flags module at a flag service. It's read
+// once at start.
+type RemoteFlagsConfig struct {
+	// URL serves the flags as a JSON object of names to booleans, e.g.
+	// {"new-checkout": true}. Empty disables polling.
+	URL string `config:"url" validate:"omitempty,url"`
+	// Interval between polls; zero means every 30s.
+	Interval time.Duration `config:"interval"`
+	// Timeout bounds each poll; zero means 5s.
+	Timeout time.Duration `config:"timeout"`
 }
 
 type Root struct {
@@ -32,4 +96,5 @@ type Root struct {
 	Server        ServerConfig        `config:"server"`
 	Observability ObservabilityConfig `config:"observability"`
 	Actuator      ActuatorConfig      `config:"actuator"`
+	Features      FeaturesConfig      `config:"features"`
 }
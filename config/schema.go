@@ -0,0 +1,144 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// SchemaType resolves a path of config keys against the struct type of schema
+// and returns the Go type of the field it addresses.
+//
+// schema may be a struct value, a pointer to a struct, or a reflect.Type.
+// Keys are matched against `config` tags (or field names when untagged)
+// case-insensitively, mirroring how the Binder matches source keys.
+//
+// Example:
+//
+//	t, ok := config.SchemaType(&Root{}, []string{"server", "readTimeout"})
+//	// t == reflect.TypeOf(time.Duration(0)), ok == true
+func SchemaType(schema any, path []string) (reflect.Type, bool) {
+	t, ok := schema.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(schema)
+	}
+	if t == nil {
+		return nil, false
+	}
+	for _, key := range path {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		f, ok := lookupField(t, key)
+		if !ok {
+			return nil, false
+		}
+		t = f.Type
+	}
+	return t, true
+}
+
+// lookupField finds the struct field of t that a source key binds to.
+// An exact tag match wins over a case-insensitive one. Fields of squashed
+// embedded structs are found as if declared on t, with an Index usable on t.
+func lookupField(t reflect.Type, key string) (reflect.StructField, bool) {
+	keys := structKeysOf(t)
+	if f, ok := keys.exact[key]; ok {
+		return f, true
+	}
+	f, ok := keys.fold[strings.ToLower(key)]
+	return f, ok
+}
+
+// structKeys indexes the bindable fields of a struct type by config key,
+// exactly and lowercased, the first field in declaration order winning.
+type structKeys struct {
+	exact map[string]reflect.StructField
+	fold  map[string]reflect.StructField
+}
+
+// structKeysCache holds a *structKeys per struct type, since the same
+// types are looked up on every Reload.
+var structKeysCache sync.Map
+
+func structKeysOf(t reflect.Type) *structKeys {
+	if v, ok := structKeysCache.Load(t); ok {
+		return v.(*structKeys)
+	}
+	keys := &structKeys{
+		exact: make(map[string]reflect.StructField),
+		fold:  make(map[string]reflect.StructField),
+	}
+	keys.add(t, nil)
+	v, _ := structKeysCache.LoadOrStore(t, keys)
+	return v.(*structKeys)
+}
+
+func (k *structKeys) add(t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		f.Index = append(slices.Clone(index), f.Index...)
+		if Squashed(f) {
+			k.add(f.Type, f.Index)
+			continue
+		}
+		name, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		if _, dup := k.exact[name]; !dup {
+			k.exact[name] = f
+		}
+		if _, dup := k.fold[strings.ToLower(name)]; !dup {
+			k.fold[strings.ToLower(name)] = f
+		}
+	}
+}
+
+// FieldKey returns the config key for f, or false if f is not bindable:
+// the name in its config tag, or the Go field name if the tag has none.
+func FieldKey(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	tag := f.Tag.Get("config")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+// Squashed reports whether f is an embedded struct tagged
+// `config:",squash"`, whose fields bind as if declared on the outer struct.
+// Untagged embedded structs bind under their type name, like other fields.
+// Sources that walk a schema use it to read the struct as Bind does.
+func Squashed(f reflect.StructField) bool {
+	if !f.Anonymous || f.Type.Kind() != reflect.Struct {
+		return false
+	}
+	_, opts, _ := strings.Cut(f.Tag.Get("config"), ",")
+	return slices.Contains(strings.Split(opts, ","), "squash")
+}
+
+// IsNested reports whether t is a struct bound field by field, rather
+// than a leaf such as time.Time or url.URL. Like Squashed, it lets sources
+// that walk a schema read the struct as Bind does.
+func IsNested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != urlType && !t.Implements(textMarshalerType) && !reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
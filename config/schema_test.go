@@ -0,0 +1,43 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSchemaType(t *testing.T) {
+	type Server struct {
+		ReadTimeout time.Duration `config:"readTimeout"`
+		Tags        []string      `config:"tags"`
+	}
+	type Root struct {
+		Server  *Server `config:"server"`
+		Name    string
+		Ignored string `config:"-"`
+	}
+
+	tests := []struct {
+		name   string
+		path   []string
+		want   reflect.Type
+		wantOK bool
+	}{
+		{"nested through pointer", []string{"server", "readTimeout"}, durationType, true},
+		{"case-insensitive", []string{"SERVER", "readtimeout"}, durationType, true},
+		{"slice field", []string{"server", "tags"}, reflect.TypeOf([]string{}), true},
+		{"untagged field uses name", []string{"name"}, reflect.TypeOf(""), true},
+		{"skipped field", []string{"ignored"}, nil, false},
+		{"missing field", []string{"server", "port"}, nil, false},
+		{"path through leaf", []string{"name", "x"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SchemaType(&Root{}, tt.path)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("SchemaType(%v) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
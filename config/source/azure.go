@@ -0,0 +1,196 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+// keyVaultRefContentType marks an App Configuration setting whose value is
+// a reference to a Key Vault secret rather than the value itself.
+const keyVaultRefContentType = "application/vnd.microsoft.appconfig.keyvaultref+json"
+
+// AzureSetting is one key-value from Azure App Configuration.
+type AzureSetting struct {
+	Key         string
+	Value       string
+	Label       string
+	ContentType string
+}
+
+// AzureAppConfigClient lists settings from an App Configuration store.
+//
+// It's implemented by a small adapter over the Azure SDK's azappconfig
+// client in the application, so this package doesn't depend on the SDK:
+//
+//	type appConfigClient struct{ c *azappconfig.Client }
+//
+//	func (a appConfigClient) ListSettings(ctx context.Context, key, label string) ([]source.AzureSetting, string, error) {
+//	    pager := a.c.NewListSettingsPager(azappconfig.SettingSelector{
+//	        KeyFilter: &key, LabelFilter: &label,
+//	    }, nil)
+//	    var out []source.AzureSetting
+//	    var token string
+//	    for pager.More() {
+//	        page, err := pager.NextPage(ctx)
+//	        if err != nil {
+//	            return nil, "", err
+//	        }
+//	        for _, s := range page.Settings {
+//	            out = append(out, source.AzureSetting{Key: *s.Key, Value: deref(s.Value),
+//	                Label: deref(s.Label), ContentType: deref(s.ContentType)})
+//	        }
+//	        token = page.SyncToken
+//	    }
+//	    return out, token, nil
+//	}
+type AzureAppConfigClient interface {
+	// ListSettings returns the settings matching the key and label filters
+	// ("" matches all keys, or settings without a label), plus a token
+	// (sync token or ETag) that changes whenever they do.
+	ListSettings(ctx context.Context, keyFilter, labelFilter string) ([]AzureSetting, string, error)
+}
+
+// SecretResolver fetches a Key Vault secret by its URI, e.g. via the Azure
+// SDK's azsecrets client.
+type SecretResolver interface {
+	GetSecret(ctx context.Context, uri string) (string, error)
+}
+
+// AzureAppConfigSource loads configuration from Azure App Configuration.
+//
+// Keys are split on ":" or "/" into nested maps, after removing Prefix, so
+// a setting "orders:server:addr" with Prefix "orders:" becomes
+// {"server": {"addr": ...}}. Values are strings, converted to field types
+// by the Binder like env values.
+//
+// Settings that are Key Vault references are resolved through KeyVault, so
+// secrets never need to be copied into App Configuration:
+//
+//	source := &source.AzureAppConfigSource{
+//	    Client:   appConfigClient{c},
+//	    KeyVault: keyVaultResolver{s},
+//	    Prefix:   "orders:",
+//	    Label:    profile, // e.g. "prod"
+//	    Interval: 30 * time.Second,
+//	}
+type AzureAppConfigSource struct {
+	// Client lists settings from the store.
+	Client AzureAppConfigClient
+
+	// KeyVault resolves Key Vault references. Loading a reference without
+	// it is an error.
+	KeyVault SecretResolver
+
+	// Prefix limits loading to keys that start with it, and is removed
+	// from them.
+	Prefix string
+
+	// Label selects settings with this label, typically the profile.
+	// Empty selects settings without a label.
+	Label string
+
+	// Interval between change polls. Zero disables watching.
+	Interval time.Duration
+}
+
+// Name returns the identifier for this source.
+func (a *AzureAppConfigSource) Name() string { return "azure-appconfig" }
+
+// StringValued reports that all values are strings, so strict binding
+// coerces them to their field types.
+func (a *AzureAppConfigSource) StringValued() bool { return true }
+
+// Load lists the settings and builds the nested map from them, resolving
+// Key Vault references.
+func (a *AzureAppConfigSource) Load(ctx context.Context) (map[string]any, error) {
+	settings, _, err := a.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any)
+	for _, s := range settings {
+		value := s.Value
+		if isKeyVaultRef(s.ContentType) {
+			if value, err = a.resolve(ctx, s); err != nil {
+				return nil, err
+			}
+		}
+		key := strings.TrimPrefix(s.Key, a.Prefix)
+		segments := strings.FieldsFunc(key, func(r rune) bool { return r == ':' || r == '/' })
+		setNestedValue(result, segments, value)
+	}
+	return result, nil
+}
+
+// Watch polls the store every Interval and sends an Event when the token
+// returned by ListSettings changes. Failed polls are skipped; the next tick
+// tries again.
+//
+// Returns nil immediately if Interval is unset, otherwise blocks until the
+// context is cancelled and returns ctx.Err().
+func (a *AzureAppConfigSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	if a.Interval <= 0 {
+		return nil
+	}
+
+	_, last, _ := a.list(ctx)
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_, current, err := a.list(ctx)
+			if err != nil || current == last {
+				continue
+			}
+			last = current
+			select {
+			case ch <- config.Event{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (a *AzureAppConfigSource) list(ctx context.Context) ([]AzureSetting, string, error) {
+	settings, token, err := a.Client.ListSettings(ctx, a.Prefix+"*", a.Label)
+	if err != nil {
+		return nil, "", fmt.Errorf("azure appconfig source: list: %w", err)
+	}
+	return settings, token, nil
+}
+
+// resolve fetches the secret a Key Vault reference setting points to.
+func (a *AzureAppConfigSource) resolve(ctx context.Context, s AzureSetting) (string, error) {
+	if a.KeyVault == nil {
+		return "", fmt.Errorf("azure appconfig source: %s is a Key Vault reference but no KeyVault resolver is set", s.Key)
+	}
+	var ref struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal([]byte(s.Value), &ref); err != nil || ref.URI == "" {
+		return "", fmt.Errorf("azure appconfig source: %s: invalid Key Vault reference", s.Key)
+	}
+	secret, err := a.KeyVault.GetSecret(ctx, ref.URI)
+	if err != nil {
+		return "", fmt.Errorf("azure appconfig source: %s: %w", s.Key, err)
+	}
+	return secret, nil
+}
+
+// isKeyVaultRef reports whether contentType (which may carry parameters
+// such as charset) marks a Key Vault reference.
+func isKeyVaultRef(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.EqualFold(strings.TrimSpace(mediaType), keyVaultRefContentType)
+}
@@ -0,0 +1,112 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+// fakeAppConfig serves settings for one label, filtering by key prefix.
+type fakeAppConfig struct {
+	mu       sync.Mutex
+	settings []AzureSetting
+	token    string
+}
+
+func (f *fakeAppConfig) ListSettings(_ context.Context, keyFilter, labelFilter string) ([]AzureSetting, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prefix := strings.TrimSuffix(keyFilter, "*")
+	var out []AzureSetting
+	for _, s := range f.settings {
+		if strings.HasPrefix(s.Key, prefix) && s.Label == labelFilter {
+			out = append(out, s)
+		}
+	}
+	return out, f.token, nil
+}
+
+type fakeKeyVault map[string]string
+
+func (v fakeKeyVault) GetSecret(_ context.Context, uri string) (string, error) {
+	if s, ok := v[uri]; ok {
+		return s, nil
+	}
+	return "", errors.New("secret not found")
+}
+
+func TestAzureAppConfigSource_Load(t *testing.T) {
+	client := &fakeAppConfig{settings: []AzureSetting{
+		{Key: "orders:server:addr", Value: ":8080", Label: "prod"},
+		{Key: "orders:server:addr", Value: ":9090", Label: "dev"},
+		{Key: "orders:db/pool/max", Value: "50", Label: "prod"},
+		{Key: "orders:db:password", Label: "prod",
+			Value:       `{"uri":"https://vault.example.net/secrets/db-password"}`,
+			ContentType: keyVaultRefContentType + ";charset=utf-8"},
+		{Key: "payments:server:addr", Value: ":7070", Label: "prod"},
+	}}
+	source := &AzureAppConfigSource{
+		Client:   client,
+		KeyVault: fakeKeyVault{"https://vault.example.net/secrets/db-password": "s3cret"},
+		Prefix:   "orders:",
+		Label:    "prod",
+	}
+
+	result, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string]any{
+		"server": map[string]any{"addr": ":8080"},
+		"db": map[string]any{
+			"pool":     map[string]any{"max": "50"},
+			"password": "s3cret",
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+
+	t.Run("reference without resolver", func(t *testing.T) {
+		source := &AzureAppConfigSource{Client: client, Prefix: "orders:", Label: "prod"}
+		if _, err := source.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "orders:db:password") {
+			t.Errorf("Load() error = %v, want error naming the reference", err)
+		}
+	})
+}
+
+func TestAzureAppConfigSource_Watch(t *testing.T) {
+	client := &fakeAppConfig{token: "1"}
+	source := &AzureAppConfigSource{Client: client, Interval: 10 * time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan config.Event, 1)
+	errCh := make(chan error, 1)
+	go func() { errCh <- source.Watch(ctx, ch) }()
+
+	time.Sleep(30 * time.Millisecond)
+	client.mu.Lock()
+	client.token = "2"
+	client.mu.Unlock()
+
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event after sync token changed")
+	}
+	cancel()
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Errorf("Watch() error = %v, want context.Canceled", err)
+	}
+
+	if err := (&AzureAppConfigSource{Client: client}).Watch(context.Background(), ch); err != nil {
+		t.Errorf("Watch() without Interval = %v, want nil", err)
+	}
+}
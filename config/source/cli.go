@@ -39,6 +39,10 @@ import (
 // the precedence chain to allow command-line flags to override all other sources.
 type CLISource struct{}
 
+// StringValued reports that all values are strings, so strict binding
+// coerces them to their field types.
+func (c *CLISource) StringValued() bool { return true }
+
 // Name returns the identifier for this source.
 func (c *CLISource) Name() string { return "cli" }
 
@@ -0,0 +1,71 @@
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// base64Decrypter is a fake scheme: payloads are base64-encoded plaintext.
+var base64Decrypter = DecrypterFunc(func(payload string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(payload)
+	return string(b), err
+})
+
+func encrypt(plain string) string {
+	return "ENC[" + base64.StdEncoding.EncodeToString([]byte(plain)) + "]"
+}
+
+func TestDecryptingSource_Load(t *testing.T) {
+	inner := &Override{}
+	inner.Set("database.host", "db.internal")
+	inner.Set("database.password", encrypt("s3cret"))
+	inner.Set("tokens", []any{encrypt("t1"), "plain"})
+	inner.Set("note", "ENC without brackets")
+
+	source := &DecryptingSource{Source: inner, Decrypter: base64Decrypter}
+	result, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"database": map[string]any{"host": "db.internal", "password": "s3cret"},
+		"tokens":   []any{"t1", "plain"},
+		"note":     "ENC without brackets",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+	if source.Name() != "override" {
+		t.Errorf("Name() = %q, want wrapped source name", source.Name())
+	}
+}
+
+func TestDecryptingSource_Load_Error(t *testing.T) {
+	inner := &Override{}
+	inner.Set("database.password", "ENC[not base64!]")
+
+	source := &DecryptingSource{Source: inner, Decrypter: base64Decrypter}
+	_, err := source.Load(context.Background())
+	if err == nil {
+		t.Fatal("Load() with bad ciphertext: expected error")
+	}
+	if !strings.Contains(err.Error(), "database.password") {
+		t.Errorf("error = %v, want it to name the key", err)
+	}
+	if strings.Contains(err.Error(), "not base64") {
+		t.Errorf("error = %v, leaks the value", err)
+	}
+}
+
+func TestDecryptingSource_Load_NilDecrypter(t *testing.T) {
+	inner := &Override{}
+	inner.Set("database.password", encrypt("s3cret"))
+
+	if _, err := (&DecryptingSource{Source: inner}).Load(context.Background()); err == nil {
+		t.Error("Load() without a Decrypter: expected error")
+	}
+}
@@ -0,0 +1,134 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"github.com/skekre98/genever/config"
+)
+
+// FromDefaults returns a source holding the values of a struct literal,
+// for in-code defaults at the bottom of the source list:
+//
+//	mgr, err := config.NewManager(&cfg, config.Options{},
+//	    source.FromDefaults(config.Root{
+//	        Server: config.ServerConfig{Addr: ":8080", ReadTimeout: 5 * time.Second},
+//	    }),
+//	    &source.FileSource{BasePath: "configs"},
+//	    &source.EnvSource{},
+//	)
+//
+// Fields map to keys by their `config` tags, like binding. Zero-valued
+// fields are omitted, so they don't mask `default` tags and later sources
+// only need to set what they change. Nested structs become nested maps;
+// other values (durations, slices, maps, and structs like time.Time that
+// marshal to text) are kept as-is.
+//
+// cfg may be a struct or a pointer to one; it's copied, slices and maps
+// included, so later changes to the original don't affect the source, and
+// each Load returns a fresh copy. Load returns an error for any other type.
+func FromDefaults(cfg any) config.ConfigSource {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	d := &defaultsSource{typ: fmt.Sprintf("%T", cfg)}
+	if v.Kind() == reflect.Struct {
+		d.data = structToMap(v)
+	}
+	return d
+}
+
+type defaultsSource struct {
+	data map[string]any // nil if cfg isn't a struct
+	typ  string
+}
+
+// Name returns the identifier for this source.
+func (d *defaultsSource) Name() string { return "defaults" }
+
+// Load returns the struct's non-zero fields as a nested map.
+func (d *defaultsSource) Load(ctx context.Context) (map[string]any, error) {
+	if d.data == nil {
+		return nil, fmt.Errorf("defaults: want a struct, got %s", d.typ)
+	}
+	return copyValue(reflect.ValueOf(d.data)).(map[string]any), nil
+}
+
+// Watch returns nil immediately; defaults never change.
+func (d *defaultsSource) Watch(ctx context.Context, ch chan<- config.Event) error { return nil }
+
+// structToMap converts the non-zero fields of struct v to a nested map
+// keyed by config tags, copying slice and map values.
+func structToMap(v reflect.Value) map[string]any {
+	out := make(map[string]any)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if config.Squashed(f) {
+			maps.Copy(out, structToMap(v.Field(i)))
+			continue
+		}
+		key, ok := config.FieldKey(f)
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.IsZero() {
+			continue
+		}
+		if config.IsNested(fv.Type()) {
+			if nested := structToMap(fv); len(nested) > 0 {
+				out[key] = nested
+			}
+			continue
+		}
+		out[key] = copyValue(fv)
+	}
+	return out
+}
+
+// copyValue returns v's value with slices and maps, and those inside them,
+// copied rather than shared.
+func copyValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return copyValue(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			setCopy(out.Index(i), v.Index(i))
+		}
+		return out.Interface()
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			setCopy(elem, iter.Value())
+			out.SetMapIndex(iter.Key(), elem)
+		}
+		return out.Interface()
+	}
+	return v.Interface()
+}
+
+// setCopy sets dst to a copy of src, leaving a nil interface as it is.
+func setCopy(dst, src reflect.Value) {
+	if c := copyValue(src); c != nil {
+		dst.Set(reflect.ValueOf(c))
+	}
+}
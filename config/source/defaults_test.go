@@ -0,0 +1,109 @@
+package source
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestFromDefaults_Load(t *testing.T) {
+	defaults := config.Root{
+		App:    config.AppInfo{Name: "orders"},
+		Server: config.ServerConfig{Addr: ":8080", ReadTimeout: 5 * time.Second},
+	}
+
+	result, err := FromDefaults(&defaults).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string]any{
+		"app":    map[string]any{"name": "orders"},
+		"server": map[string]any{"addr": ":8080", "readTimeout": 5 * time.Second},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+
+	result, _ = FromDefaults(config.Root{}).Load(context.Background())
+	if len(result) != 0 {
+		t.Errorf("Load() of zero struct = %v, want empty map", result)
+	}
+}
+
+func TestFromDefaults_Copies(t *testing.T) {
+	defaults := config.AppInfo{Name: "orders"}
+	source := FromDefaults(&defaults)
+	defaults.Name = "changed"
+
+	result, _ := source.Load(context.Background())
+	if got := result["name"]; got != "orders" {
+		t.Errorf("name = %v, want value at construction", got)
+	}
+}
+
+func TestFromDefaults_CopiesContainers(t *testing.T) {
+	type Defaults struct {
+		Hosts  []string         `config:"hosts"`
+		Limits map[string][]int `config:"limits"`
+		Extra  map[string]any   `config:"extra"`
+	}
+	defaults := Defaults{
+		Hosts:  []string{"a", "b"},
+		Limits: map[string][]int{"rps": {10}},
+		Extra:  map[string]any{"tags": []any{"x"}},
+	}
+	source := FromDefaults(defaults)
+	defaults.Hosts[0] = "changed"
+	defaults.Limits["rps"][0] = 0
+
+	first, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	first["hosts"].([]string)[1] = "changed"
+	first["limits"].(map[string][]int)["rps"][0] = 0
+	first["extra"].(map[string]any)["tags"].([]any)[0] = "changed"
+
+	second, _ := source.Load(context.Background())
+	expected := map[string]any{
+		"hosts":  []string{"a", "b"},
+		"limits": map[string][]int{"rps": {10}},
+		"extra":  map[string]any{"tags": []any{"x"}},
+	}
+	if !reflect.DeepEqual(second, expected) {
+		t.Errorf("Load() = %v, want %v unaffected by changes to the original or an earlier Load", second, expected)
+	}
+}
+
+func TestFromDefaults_NotStruct(t *testing.T) {
+	if _, err := FromDefaults("nope").Load(context.Background()); err == nil {
+		t.Error("Load() with non-struct: expected error")
+	}
+}
+
+func TestFromDefaults_BaseLayer(t *testing.T) {
+	var cfg config.Root
+	override := &Override{}
+	override.Set("server.addr", ":9090")
+
+	_, err := config.NewManager(&cfg, config.Options{},
+		FromDefaults(config.Root{
+			App:    config.AppInfo{Name: "orders", Version: "1.0.0"},
+			Server: config.ServerConfig{Addr: ":8080", ReadTimeout: 5 * time.Second},
+		}),
+		override,
+	)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	if cfg.App.Name != "orders" || cfg.Server.ReadTimeout != 5*time.Second {
+		t.Errorf("config = %+v, want defaults as the base layer", cfg)
+	}
+	if cfg.Server.Addr != ":9090" {
+		t.Errorf("Server.Addr = %q, want later source to override", cfg.Server.Addr)
+	}
+}
@@ -0,0 +1,275 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skekre98/genever/config"
+)
+
+// DotEnvSource loads configuration from a .env file, for local development
+// without exporting its variables into the environment.
+//
+// Lines are KEY=VALUE pairs, mapped to nested keys exactly as EnvSource
+// maps environment variables: only keys with the prefix are loaded, and
+// GENEVER_SERVER_PORT=8080 becomes {server: {port: "8080"}}. Blank lines
+// and lines starting with # are skipped, a leading "export " is allowed,
+// and values may be quoted:
+//
+//	# local overrides
+//	GENEVER_DB_URL="postgres://localhost/orders"
+//	GENEVER_APP_NAME='orders (dev)'
+//	export GENEVER_SERVER_PORT=8081  # trailing comment
+//
+// Double-quoted values support \n, \t, \" and \\ escapes; single-quoted
+// values are literal. Unquoted values end at a " #" comment.
+//
+// With Interpolate, unquoted and double-quoted values may reference other
+// variables as docker compose env files do: ${NAME} or $NAME, ${NAME:-def}
+// (def if NAME is unset or empty), ${NAME-def} (def if NAME is unset), and
+// $$ for a literal $. References resolve against the variables defined
+// earlier, in this file or one before it in Paths, then the process
+// environment; an unresolved reference without a default is an error.
+//
+//	DB_HOST=localhost
+//	GENEVER_DB_URL=postgres://${DB_USER:-app}@${DB_HOST}:5432/orders
+//
+// In precedence it belongs between FileSource and EnvSource, so the file
+// overrides checked-in config and real environment variables override
+// the file:
+//
+//	config.NewManager(&cfg, config.Options{},
+//	    &source.FileSource{BasePath: "configs"},
+//	    &source.DotEnvSource{Path: ".env", Optional: true},
+//	    &source.EnvSource{},
+//	)
+type DotEnvSource struct {
+	// Path is the .env file to read.
+	Path string
+
+	// Paths lists further files read after Path, e.g. a shared .env and a
+	// personal .env.local. A variable set in a later file replaces an
+	// earlier one.
+	Paths []string
+
+	// Optional makes missing files load nothing instead of failing, so
+	// the source can stay configured where there's no .env file.
+	Optional bool
+
+	// Interpolate expands variable references in values.
+	Interpolate bool
+
+	// Prefix replaces ENV_PREFIX, as on EnvSource.
+	Prefix string
+
+	// Delimiter separates nesting levels in keys, as on EnvSource.
+	Delimiter string
+}
+
+// Name returns the identifier for this source.
+func (d *DotEnvSource) Name() string { return "dotenv" }
+
+// StringValued reports that all values are strings, so strict binding
+// coerces them to their field types.
+func (d *DotEnvSource) StringValued() bool { return true }
+
+// Load reads and parses the files.
+//
+// Returns an error if a file can't be read (unless it's missing and
+// Optional is set), has a line that isn't a KEY=VALUE pair, or, with
+// Interpolate, has an unresolved reference.
+func (d *DotEnvSource) Load(ctx context.Context) (map[string]any, error) {
+	vars := map[string]string{}
+	var lines []string
+	for _, path := range append([]string{d.Path}, d.Paths...) {
+		var err error
+		if lines, err = d.readFile(path, vars, lines); err != nil {
+			return nil, err
+		}
+	}
+
+	env := &EnvSource{Prefix: d.Prefix, Delimiter: d.Delimiter}
+	return envVarsMap(lines, env.prefix(), env.delimiter(), nil), nil
+}
+
+// readFile parses the file at path, appending its KEY=value lines to lines
+// and recording its variables in vars for later references.
+func (d *DotEnvSource) readFile(path string, vars map[string]string, lines []string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if d.Optional && errors.Is(err, os.ErrNotExist) {
+			return lines, nil
+		}
+		return nil, fmt.Errorf("dotenv source: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, raw, ok := parseEnvLine(line)
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("dotenv source: %s:%d: expected KEY=VALUE", path, n)
+		}
+		value, literal, err := dotEnvValue(strings.TrimSpace(raw))
+		if err == nil && d.Interpolate && !literal {
+			value, err = interpolate(value, vars)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("dotenv source: %s:%d: %w", path, n, err)
+		}
+		vars[key] = value
+		lines = append(lines, key+"="+value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("dotenv source: %w", err)
+	}
+	return lines, nil
+}
+
+// Watch is not implemented for DotEnvSource.
+// Returns nil immediately; use Options.RefreshInterval to pick up edits.
+func (d *DotEnvSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	return nil
+}
+
+// dotEnvValue unquotes a raw .env value. literal reports a single-quoted
+// value, which isn't interpolated.
+func dotEnvValue(raw string) (value string, literal bool, err error) {
+	if raw == "" {
+		return "", false, nil
+	}
+	switch quote := raw[0]; quote {
+	case '"', '\'':
+		end := closingQuote(raw, quote)
+		if end < 0 {
+			return "", false, errors.New("unterminated quoted value")
+		}
+		if rest := strings.TrimSpace(raw[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", false, fmt.Errorf("unexpected %q after quoted value", rest)
+		}
+		inner := raw[1:end]
+		if quote == '\'' {
+			return inner, true, nil
+		}
+		return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(inner), false, nil
+	}
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+	return raw, false, nil
+}
+
+// interpolate expands the variable references in s, looking names up in
+// vars and then the process environment.
+func interpolate(s string, vars map[string]string) (string, error) {
+	lookup := func(name string) (string, bool) {
+		if v, ok := vars[name]; ok {
+			return v, true
+		}
+		return os.LookupEnv(name)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch next := s[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated reference in %q", s)
+			}
+			expr := s[i+2 : i+end]
+			v, err := expand(expr, lookup)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(v)
+			i += end
+		case isNameByte(next, true):
+			end := i + 2
+			for end < len(s) && isNameByte(s[end], false) {
+				end++
+			}
+			name := s[i+1 : end]
+			v, ok := lookup(name)
+			if !ok {
+				return "", fmt.Errorf("unresolved reference $%s", name)
+			}
+			b.WriteString(v)
+			i = end - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// expand resolves the inside of a ${...} reference: NAME, NAME:-default or
+// NAME-default.
+func expand(expr string, lookup func(string) (string, bool)) (string, error) {
+	name, def, hasDef := expr, "", false
+	emptyIsUnset := false
+	if i := strings.IndexByte(expr, '-'); i > 0 {
+		name, def, hasDef = expr[:i], expr[i+1:], true
+		if strings.HasSuffix(name, ":") {
+			name, emptyIsUnset = name[:len(name)-1], true
+		}
+	}
+	if !validName(name) {
+		return "", fmt.Errorf("invalid reference ${%s}", expr)
+	}
+
+	v, ok := lookup(name)
+	if ok && (v != "" || !emptyIsUnset) {
+		return v, nil
+	}
+	if hasDef {
+		return def, nil
+	}
+	return "", fmt.Errorf("unresolved reference ${%s}", name)
+}
+
+func validName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if !isNameByte(name[i], i == 0) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// isNameByte reports whether c can appear in a variable name (first
+// excludes digits).
+func isNameByte(c byte, first bool) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || !first && '0' <= c && c <= '9'
+}
+
+// closingQuote returns the index of the quote closing the value opened at
+// s[0], skipping backslash-escaped ones in double quotes, or -1.
+func closingQuote(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '"':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,148 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDotEnvSource_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# local overrides
+GENEVER_DB_URL="postgres://localhost/orders?sslmode=disable"
+GENEVER_APP_NAME='orders (dev) # not a comment'
+export GENEVER_SERVER_PORT=8081  # trailing comment
+GENEVER_APP_GREETING="hello\nworld \"quoted\""
+
+OTHER_VAR=ignored
+GENEVER_EMPTY=
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+
+	result, err := (&DotEnvSource{Path: path}).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string]any{
+		"db":     map[string]any{"url": "postgres://localhost/orders?sslmode=disable"},
+		"app":    map[string]any{"name": "orders (dev) # not a comment", "greeting": "hello\nworld \"quoted\""},
+		"server": map[string]any{"port": "8081"},
+		"empty":  "",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+}
+
+func TestDotEnvSource_PrefixAndDelimiter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("ORDERS_CACHE__REDIS_HOST=redis\nGENEVER_CACHE__TTL=5s\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+
+	result, err := (&DotEnvSource{Path: path, Prefix: "ORDERS_", Delimiter: "__"}).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string]any{"cache": map[string]any{"redis_host": "redis"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+}
+
+func TestDotEnvSource_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing file", func(t *testing.T) {
+		missing := filepath.Join(dir, "missing.env")
+		if _, err := (&DotEnvSource{Path: missing}).Load(context.Background()); err == nil {
+			t.Error("Load() error = nil, want error for a missing file")
+		}
+		result, err := (&DotEnvSource{Path: missing, Optional: true}).Load(context.Background())
+		if err != nil || len(result) != 0 {
+			t.Errorf("Load() with Optional = %v, %v, want empty map", result, err)
+		}
+	})
+
+	for _, tt := range []struct {
+		name, content, want string
+	}{
+		{"no equals", "GENEVER_A=1\nGENEVER_B\n", ":2: expected KEY=VALUE"},
+		{"unterminated quote", `GENEVER_A="open`, "unterminated quoted value"},
+		{"text after quote", `GENEVER_A="a" b`, `unexpected "b"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "-")+".env")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write .env: %v", err)
+			}
+			_, err := (&DotEnvSource{Path: path}).Load(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDotEnvSource_Interpolate(t *testing.T) {
+	t.Setenv("DEPLOY_REGION", "eu-west-1")
+
+	dir := t.TempDir()
+	shared := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	if err := os.WriteFile(shared, []byte(`DB_HOST=localhost
+DB_PORT=5432
+GENEVER_DB_URL=postgres://${DB_USER:-app}@${DB_HOST}:$DB_PORT/orders
+GENEVER_APP_REGION="${DEPLOY_REGION}"
+GENEVER_APP_PRICE='$5 ${NOT_EXPANDED}'
+GENEVER_APP_ESCAPED=pa$$word
+`), 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+	if err := os.WriteFile(local, []byte("DB_HOST=db.local\nGENEVER_CACHE_URL=redis://${DB_HOST}:6379\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .env.local: %v", err)
+	}
+
+	result, err := (&DotEnvSource{Path: shared, Paths: []string{local}, Interpolate: true}).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string]any{
+		"db": map[string]any{"url": "postgres://app@localhost:5432/orders"},
+		"app": map[string]any{
+			"region":  "eu-west-1",
+			"price":   "$5 ${NOT_EXPANDED}",
+			"escaped": "pa$word",
+		},
+		"cache": map[string]any{"url": "redis://db.local:6379"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+}
+
+func TestDotEnvSource_InterpolateUnresolved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("GENEVER_A=ok\nGENEVER_DB_URL=postgres://${GENEVER_TEST_UNSET_HOST}/orders\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+
+	_, err := (&DotEnvSource{Path: path, Interpolate: true}).Load(context.Background())
+	if err == nil || !strings.Contains(err.Error(), ":2: unresolved reference ${GENEVER_TEST_UNSET_HOST}") {
+		t.Errorf("Load() error = %v, want unresolved reference on line 2", err)
+	}
+
+	// Without Interpolate, references are kept as written.
+	result, err := (&DotEnvSource{Path: path}).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := getNestedValue(result, []string{"db", "url"}); got != "postgres://${GENEVER_TEST_UNSET_HOST}/orders" {
+		t.Errorf("db.url = %v", got)
+	}
+}
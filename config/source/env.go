@@ -3,13 +3,15 @@ package source
 import (
 	"context"
 	"os"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/skekre98/genever/config"
 )
 
-// ENV_PREFIX is the required prefix for environment variables.
-// Only variables starting with this prefix are loaded.
+// ENV_PREFIX is the default prefix for environment variables.
+// Only variables starting with the prefix are loaded.
 const ENV_PREFIX = "GENEVER_"
 
 // EnvSource loads configuration from environment variables.
@@ -18,8 +20,8 @@ const ENV_PREFIX = "GENEVER_"
 // a nested map structure using underscores as delimiters.
 //
 // Environment variable format:
-//   - Must start with "GENEVER_" prefix
-//   - Remaining parts are split by underscores
+//   - Must start with the prefix, "GENEVER_" unless Prefix is set
+//   - Remaining parts are split by underscores (see Delimiter)
 //   - Converted to lowercase
 //   - Creates nested maps for hierarchical structure
 //
@@ -35,55 +37,278 @@ const ENV_PREFIX = "GENEVER_"
 //	  -> {app: {name: "myapp"}}
 //
 // All values are returned as strings. Type conversion happens during binding.
+// GENEVER_SOURCES is reserved for SourcesFromEnv and not loaded.
 //
 // Conflict handling:
-// If a leaf value already exists, nested values cannot be created at that path.
-// For example, setting both GENEVER_DB=value and GENEVER_DB_HOST=localhost
-// will preserve the first one and skip the second.
-type EnvSource struct{}
+// A leaf value wins over nested values at the same path, whatever the order
+// of the environment. For example, setting both GENEVER_DB=value and
+// GENEVER_DB_HOST=localhost yields {db: "value"}. Conflicts between sources
+// are resolved by the Manager's merge (see config.MergeMaps).
+//
+// Keys with underscores:
+// With Delimiter set to "__", only double underscores nest and single ones
+// stay in the key:
+//
+//	GENEVER_CACHE__REDIS__HOST=redis
+//	  -> {cache: {redis: {host: "redis"}}}
+//
+//	GENEVER_API_KEY=secret
+//	  -> {api_key: "secret"}
+//
+// List values:
+// When ListSeparator is set, values can be split into lists. With a Schema,
+// only values that bind to slice fields are split; with SplitAll, every value
+// containing the separator is split.
+//
+//	GENEVER_SERVER_TAGS=web,api,prod
+//	  -> {server: {tags: ["web", "api", "prod"]}}
+type EnvSource struct {
+	// Prefix replaces ENV_PREFIX, so services sharing a host can scope
+	// their variables, e.g. "ORDERS_" for ORDERS_SERVER_PORT. Empty means
+	// ENV_PREFIX.
+	Prefix string
+
+	// Delimiter separates nesting levels in variable names. Empty means
+	// "_". Set it to "__" for keys that contain underscores themselves.
+	Delimiter string
+
+	// ListSeparator splits list values into []any. Empty disables splitting.
+	ListSeparator string
+
+	// Schema is the config struct (or a pointer to it) used to decide which
+	// values are lists: only values bound to slice fields are split.
+	Schema any
+
+	// SplitAll splits every value containing ListSeparator, regardless of
+	// Schema.
+	SplitAll bool
+
+	// SchemaKeys maps variable names to the config keys of Schema instead of
+	// lowercasing them, so GENEVER_SERVER_READ_TIMEOUT (or
+	// GENEVER_SERVER_READTIMEOUT) becomes {server: {readTimeout: ...}}.
+	// Underscores may separate words within a key; the longest key that
+	// matches wins. Variables that don't match the schema keep the default
+	// lowercase mapping. Requires Schema.
+	SchemaKeys bool
+
+	// PollInterval, if set, makes Watch check the environment for changes
+	// on this interval, for processes whose environment is changed in
+	// place. Zero disables watching.
+	PollInterval time.Duration
+}
+
+// StringValued reports that all values are strings, so strict binding
+// coerces them to their field types.
+func (e *EnvSource) StringValued() bool { return true }
 
 // Name returns the identifier for this source.
 func (e *EnvSource) Name() string { return "env" }
 
-// Load reads all environment variables with the GENEVER_ prefix.
+// EnvVarName returns the variable that sets the config path, e.g.
+// GENEVER_SERVER_READTIMEOUT for [server readTimeout].
+func (e *EnvSource) EnvVarName(path []string) string {
+	return e.prefix() + strings.ToUpper(strings.Join(path, e.delimiter()))
+}
+
+// delimiter returns Delimiter, or "_" if it's unset.
+func (e *EnvSource) delimiter() string {
+	if e.Delimiter != "" {
+		return e.Delimiter
+	}
+	return "_"
+}
+
+// prefix returns Prefix, or ENV_PREFIX if it's unset.
+func (e *EnvSource) prefix() string {
+	if e.Prefix != "" {
+		return e.Prefix
+	}
+	return ENV_PREFIX
+}
+
+// Load reads all environment variables with the prefix (GENEVER_ by default).
 //
 // The context is currently not used but is included for API consistency.
 //
 // Returns a map with nested structure based on underscore-delimited variable names.
 // Never returns an error - missing or invalid environment variables are ignored.
 func (e *EnvSource) Load(ctx context.Context) (map[string]any, error) {
-	return loadEnvVars(), nil
+	var mapKey func([]string) []string
+	if e.SchemaKeys && e.Schema != nil {
+		mapKey = e.schemaKey
+	}
+	result := loadEnvVars(e.prefix(), e.delimiter(), mapKey)
+	if e.ListSeparator != "" && (e.SplitAll || e.Schema != nil) {
+		e.splitLists(result, nil)
+	}
+	return result, nil
 }
 
-// Watch is not implemented for EnvSource.
-// Returns nil immediately, indicating that environment watching is not supported.
+// Watch polls the environment every PollInterval and sends an Event when
+// the loaded variables differ from the previous poll.
 //
-// Environment variables typically don't change during process runtime.
-// If you need to detect changes, consider implementing a polling mechanism.
+// Returns nil immediately if PollInterval is unset, as environment
+// variables typically don't change during process runtime. Otherwise
+// blocks until the context is cancelled and returns ctx.Err().
 func (e *EnvSource) Watch(ctx context.Context, ch chan<- config.Event) error {
-	return nil
+	if e.PollInterval <= 0 {
+		return nil
+	}
+
+	last, _ := e.Load(ctx)
+	ticker := time.NewTicker(e.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			current, _ := e.Load(ctx)
+			if reflect.DeepEqual(current, last) {
+				continue
+			}
+			last = current
+			select {
+			case ch <- config.Event{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
 }
 
-func loadEnvVars() map[string]any {
+// splitLists replaces list values in m with []any, in place.
+func (e *EnvSource) splitLists(m map[string]any, path []string) {
+	for k, v := range m {
+		p := append(path[:len(path):len(path)], k)
+		switch val := v.(type) {
+		case map[string]any:
+			e.splitLists(val, p)
+		case string:
+			if e.isList(p, val) {
+				m[k] = splitList(val, e.ListSeparator)
+			}
+		}
+	}
+}
+
+func (e *EnvSource) isList(path []string, value string) bool {
+	if e.SplitAll {
+		return strings.Contains(value, e.ListSeparator)
+	}
+	t, ok := config.SchemaType(e.Schema, path)
+	if !ok {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	// []byte takes the raw string, not a list.
+	return t.Elem().Kind() != reflect.Uint8
+}
+
+func splitList(value, sep string) []any {
+	if value == "" {
+		return []any{}
+	}
+	parts := strings.Split(value, sep)
+	list := make([]any, len(parts))
+	for i, p := range parts {
+		list[i] = strings.TrimSpace(p)
+	}
+	return list
+}
+
+// schemaKey maps lowercased variable segments to config keys of Schema, or
+// returns segments unchanged if they don't match it.
+func (e *EnvSource) schemaKey(segments []string) []string {
+	t, ok := e.Schema.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(e.Schema)
+	}
+	if keys, ok := matchSchema(t, segments); ok {
+		return keys
+	}
+	return segments
+}
+
+// matchSchema resolves segments against t, joining consecutive segments
+// into one key where they match a field (longest first) and backtracking
+// when the remainder doesn't resolve. Underscores are ignored in matching,
+// so with a "__" delimiter the segment "read_timeout" matches readTimeout.
+func matchSchema(t reflect.Type, segments []string) ([]string, bool) {
+	if len(segments) == 0 {
+		return nil, true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for n := len(segments); n > 0; n-- {
+			joined := strings.ReplaceAll(strings.Join(segments[:n], ""), "_", "")
+			for i := 0; i < t.NumField(); i++ {
+				f := t.Field(i)
+				if config.Squashed(f) {
+					if path, ok := matchSchema(f.Type, segments); ok {
+						return path, true
+					}
+					continue
+				}
+				key, ok := config.FieldKey(f)
+				if !ok || !strings.EqualFold(strings.ReplaceAll(key, "_", ""), joined) {
+					continue
+				}
+				if rest, ok := matchSchema(f.Type, segments[n:]); ok {
+					return append([]string{key}, rest...), true
+				}
+			}
+		}
+	case reflect.Map:
+		// Map keys are free-form; each takes one segment.
+		if rest, ok := matchSchema(t.Elem(), segments[1:]); ok {
+			return append([]string{segments[0]}, rest...), true
+		}
+	}
+	return nil, false
+}
+
+// loadEnvVars reads the variables starting with prefix into a nested map,
+// splitting the rest of their names on delimiter. mapKey, if non-nil,
+// rewrites the lowercased name segments into the key path.
+func loadEnvVars(prefix, delimiter string, mapKey func([]string) []string) map[string]any {
+	return envVarsMap(os.Environ(), prefix, delimiter, mapKey)
+}
+
+// envVarsMap is loadEnvVars over the KEY=value lines in env.
+func envVarsMap(env []string, prefix, delimiter string, mapKey func([]string) []string) map[string]any {
 	result := make(map[string]any)
 
-	for _, env := range os.Environ() {
-		key, value, found := parseEnvLine(env)
+	for _, line := range env {
+		key, value, found := parseEnvLine(line)
 		if !found {
 			continue
 		}
 
-		if !strings.HasPrefix(key, ENV_PREFIX) {
+		if !strings.HasPrefix(key, prefix) || key == SOURCES_ENV {
 			continue
 		}
 
-		key = strings.TrimPrefix(key, ENV_PREFIX)
+		key = strings.TrimPrefix(key, prefix)
 		key = strings.ToLower(key)
 
-		segments := strings.Split(key, "_")
+		segments := strings.Split(key, delimiter)
 		if len(segments) == 0 {
 			continue
 		}
+		if mapKey != nil {
+			segments = mapKey(segments)
+		}
 
 		setNestedValue(result, segments, value)
 	}
@@ -99,7 +324,7 @@ func parseEnvLine(env string) (string, string, bool) {
 	return parts[0], parts[1], true
 }
 
-func setNestedValue(m map[string]any, segments []string, value string) {
+func setNestedValue(m map[string]any, segments []string, value any) {
 	current := m
 
 	for i, segment := range segments {
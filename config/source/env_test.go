@@ -2,9 +2,15 @@ package source
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
 )
 
 func TestEnvSource_Name(t *testing.T) {
@@ -269,7 +275,7 @@ func TestLoadEnvVars_PrefixFiltering(t *testing.T) {
 	os.Setenv("GENEVER_ALSO_INCLUDED", "yes")
 	os.Setenv("genever_lowercase", "no") // Should not match - case sensitive
 
-	result := loadEnvVars()
+	result := loadEnvVars(ENV_PREFIX, "_", nil)
 
 	// Should have 2 entries (both GENEVER_ prefixed)
 	if len(result) != 2 {
@@ -290,6 +296,108 @@ func TestLoadEnvVars_PrefixFiltering(t *testing.T) {
 	}
 }
 
+func TestEnvSource_Prefix(t *testing.T) {
+	originalEnv := os.Environ()
+	defer restoreEnv(originalEnv)
+
+	os.Clearenv()
+	os.Setenv("GENEVER_SERVER_PORT", "8080")
+	os.Setenv("APP1_SERVER_PORT", "9001")
+	os.Setenv("APP2_SERVER_PORT", "9002")
+
+	tests := []struct {
+		prefix string
+		want   string
+		envVar string
+	}{
+		{"", "8080", "GENEVER_SERVER_PORT"},
+		{"APP1_", "9001", "APP1_SERVER_PORT"},
+		{"APP2_", "9002", "APP2_SERVER_PORT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.envVar, func(t *testing.T) {
+			source := &EnvSource{Prefix: tt.prefix}
+			result, err := source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(result) != 1 {
+				t.Errorf("Load() = %v, want only server", result)
+			}
+			if got := getNestedValue(result, []string{"server", "port"}); got != tt.want {
+				t.Errorf("server.port = %v, want %s", got, tt.want)
+			}
+			if got := source.EnvVarName([]string{"server", "port"}); got != tt.envVar {
+				t.Errorf("EnvVarName() = %s, want %s", got, tt.envVar)
+			}
+		})
+	}
+}
+
+func TestEnvSource_Delimiter(t *testing.T) {
+	originalEnv := os.Environ()
+	defer restoreEnv(originalEnv)
+
+	os.Clearenv()
+	os.Setenv("GENEVER_CACHE__REDIS__HOST", "redis")
+	os.Setenv("GENEVER_API_KEY", "secret")
+	os.Setenv("GENEVER_DB__MAX_CONNS", "10")
+
+	tests := []struct {
+		name      string
+		delimiter string
+		want      map[string]any
+	}{
+		{
+			name: "default",
+			want: map[string]any{
+				"cache": map[string]any{"redis": map[string]any{"host": "redis"}},
+				"api":   map[string]any{"key": "secret"},
+				"db":    map[string]any{"max": map[string]any{"conns": "10"}},
+			},
+		},
+		{
+			name:      "double underscore",
+			delimiter: "__",
+			want: map[string]any{
+				"cache":   map[string]any{"redis": map[string]any{"host": "redis"}},
+				"api_key": "secret",
+				"db":      map[string]any{"max_conns": "10"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := (&EnvSource{Delimiter: tt.delimiter}).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.want) {
+				t.Errorf("Load() = %v, want %v", result, tt.want)
+			}
+		})
+	}
+
+	t.Run("schema keys", func(t *testing.T) {
+		type AppConfig struct {
+			DB struct {
+				MaxConns int `config:"maxConns"`
+			} `config:"db"`
+		}
+		source := &EnvSource{Delimiter: "__", Schema: AppConfig{}, SchemaKeys: true}
+		result, err := source.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := getNestedValue(result, []string{"db", "maxConns"}); got != "10" {
+			t.Errorf("db.maxConns = %v, want 10 (result %v)", got, result)
+		}
+		if got := source.EnvVarName([]string{"db", "maxConns"}); got != "GENEVER_DB__MAXCONNS" {
+			t.Errorf("EnvVarName() = %s, want GENEVER_DB__MAXCONNS", got)
+		}
+	})
+}
+
 func TestLoadEnvVars_CaseConversion(t *testing.T) {
 	// Save original environment
 	originalEnv := os.Environ()
@@ -299,7 +407,7 @@ func TestLoadEnvVars_CaseConversion(t *testing.T) {
 	os.Setenv("GENEVER_UPPERCASE_KEY", "value")
 	os.Setenv("GENEVER_MixedCase_Key", "value2")
 
-	result := loadEnvVars()
+	result := loadEnvVars(ENV_PREFIX, "_", nil)
 
 	// Keys should be lowercase
 	if _, exists := result["uppercase"]; !exists {
@@ -321,7 +429,7 @@ func TestLoadEnvVars_ComplexNesting(t *testing.T) {
 	os.Setenv("GENEVER_A_B_X", "branch1")
 	os.Setenv("GENEVER_A_Y", "branch2")
 
-	result := loadEnvVars()
+	result := loadEnvVars(ENV_PREFIX, "_", nil)
 
 	// Verify deep nesting
 	if val := getNestedValue(result, []string{"a", "b", "c", "d"}); val != "deep" {
@@ -339,6 +447,190 @@ func TestLoadEnvVars_ComplexNesting(t *testing.T) {
 }
 
 // Helper function to get nested value from map
+func TestEnvSource_Load_Lists(t *testing.T) {
+	type Server struct {
+		Tags []string `config:"tags"`
+		Key  []byte   `config:"key"`
+		Host string   `config:"host"`
+	}
+	type Root struct {
+		Server Server   `config:"server"`
+		Zones  []string `config:"zones"`
+	}
+
+	tests := []struct {
+		name   string
+		source *EnvSource
+		env    map[string]string
+		want   map[string]any
+	}{
+		{
+			name:   "comma separator guided by schema",
+			source: &EnvSource{ListSeparator: ",", Schema: &Root{}},
+			env: map[string]string{
+				"GENEVER_SERVER_TAGS": "web, api,prod",
+				"GENEVER_SERVER_HOST": "a,b",
+				"GENEVER_SERVER_KEY":  "x,y",
+			},
+			want: map[string]any{
+				"server": map[string]any{
+					"tags": []any{"web", "api", "prod"},
+					"host": "a,b",
+					"key":  "x,y",
+				},
+			},
+		},
+		{
+			name:   "custom separator guided by schema",
+			source: &EnvSource{ListSeparator: ";", Schema: Root{}},
+			env: map[string]string{
+				"GENEVER_ZONES": "us-east-1a;us-east-1b",
+			},
+			want: map[string]any{
+				"zones": []any{"us-east-1a", "us-east-1b"},
+			},
+		},
+		{
+			name:   "split all without schema",
+			source: &EnvSource{ListSeparator: "|", SplitAll: true},
+			env: map[string]string{
+				"GENEVER_HOSTS": "a|b",
+				"GENEVER_NAME":  "single",
+			},
+			want: map[string]any{
+				"hosts": []any{"a", "b"},
+				"name":  "single",
+			},
+		},
+		{
+			name:   "no separator leaves values as strings",
+			source: &EnvSource{Schema: &Root{}},
+			env: map[string]string{
+				"GENEVER_ZONES": "a,b",
+			},
+			want: map[string]any{
+				"zones": "a,b",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalEnv := os.Environ()
+			defer restoreEnv(originalEnv)
+
+			os.Clearenv()
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+
+			got, err := tt.source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvSource_Load_SchemaKeys(t *testing.T) {
+	type TLS struct {
+		CertFile string `config:"certFile"`
+	}
+	type Server struct {
+		Read        string            `config:"read"`
+		ReadTimeout string            `config:"readTimeout"`
+		MaxConns    int               `config:"max_conns"`
+		TLS         TLS               `config:"tls"`
+		Labels      map[string]string `config:"labels"`
+	}
+	type Root struct {
+		Server Server `config:"server"`
+	}
+
+	tests := []struct {
+		name   string
+		source *EnvSource
+		env    map[string]string
+		want   map[string]any
+	}{
+		{
+			name:   "underscores map to camelCase keys",
+			source: &EnvSource{Schema: &Root{}, SchemaKeys: true},
+			env: map[string]string{
+				"GENEVER_SERVER_READ_TIMEOUT":  "5s",
+				"GENEVER_SERVER_READ":          "yes",
+				"GENEVER_SERVER_TLS_CERT_FILE": "cert.pem",
+				"GENEVER_SERVER_MAX_CONNS":     "100",
+			},
+			want: map[string]any{
+				"server": map[string]any{
+					"readTimeout": "5s",
+					"read":        "yes",
+					"max_conns":   "100",
+					"tls":         map[string]any{"certFile": "cert.pem"},
+				},
+			},
+		},
+		{
+			name:   "joined words and map keys",
+			source: &EnvSource{Schema: Root{}, SchemaKeys: true},
+			env: map[string]string{
+				"GENEVER_SERVER_READTIMEOUT": "5s",
+				"GENEVER_SERVER_LABELS_TEAM": "orders",
+			},
+			want: map[string]any{
+				"server": map[string]any{
+					"readTimeout": "5s",
+					"labels":      map[string]any{"team": "orders"},
+				},
+			},
+		},
+		{
+			name:   "unknown variables keep the default mapping",
+			source: &EnvSource{Schema: &Root{}, SchemaKeys: true},
+			env: map[string]string{
+				"GENEVER_CACHE_TTL": "5m",
+			},
+			want: map[string]any{
+				"cache": map[string]any{"ttl": "5m"},
+			},
+		},
+		{
+			name:   "off by default",
+			source: &EnvSource{Schema: &Root{}},
+			env: map[string]string{
+				"GENEVER_SERVER_READ_TIMEOUT": "5s",
+			},
+			want: map[string]any{
+				"server": map[string]any{"read": map[string]any{"timeout": "5s"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalEnv := os.Environ()
+			defer restoreEnv(originalEnv)
+
+			os.Clearenv()
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+
+			got, err := tt.source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
 func getNestedValue(m map[string]any, path []string) string {
 	current := m
 	for i, key := range path {
@@ -366,3 +658,178 @@ func restoreEnv(originalEnv []string) {
 		}
 	}
 }
+
+func TestEnvSource_MissingRequiredSuggestsEnvVar(t *testing.T) {
+	originalEnv := os.Environ()
+	defer restoreEnv(originalEnv)
+
+	os.Clearenv()
+	os.Setenv("GENEVER_NAME", "orders")
+
+	type DBConfig struct {
+		URL string `config:"url" validate:"required"`
+	}
+	type AppConfig struct {
+		Name string   `config:"name" validate:"required"`
+		DB   DBConfig `config:"db"`
+	}
+
+	var cfg AppConfig
+	_, err := config.NewManager(&cfg, config.Options{}, &EnvSource{})
+	if err == nil {
+		t.Fatal("NewManager() expected error for missing required field")
+	}
+	if !strings.Contains(err.Error(), "GENEVER_DB_URL (db.url)") {
+		t.Errorf("error = %v, want it to suggest GENEVER_DB_URL", err)
+	}
+	if strings.Contains(err.Error(), "GENEVER_NAME") {
+		t.Errorf("error = %v, should not suggest a satisfied field", err)
+	}
+
+	var bindErr *config.BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("expected BindError, got %T", err)
+	}
+	if got := config.MissingRequired(err, cfg); len(got) != 1 || got[0] != "db.url" {
+		t.Errorf("MissingRequired() = %v, want [db.url]", got)
+	}
+}
+
+func TestEnvSource_MissingRequiredSkipsListElements(t *testing.T) {
+	originalEnv := os.Environ()
+	defer restoreEnv(originalEnv)
+	os.Clearenv()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "application.yaml")
+	if err := os.WriteFile(base, []byte("ports:\n  - number: 8080\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	type PortConfig struct {
+		Name   string `config:"name" validate:"required"`
+		Number int    `config:"number"`
+	}
+	type AppConfig struct {
+		Name  string       `config:"name" validate:"required"`
+		Ports []PortConfig `config:"ports" validate:"dive"`
+	}
+
+	var cfg AppConfig
+	_, err := config.NewManager(&cfg, config.Options{}, &FileSource{BasePath: base}, &EnvSource{})
+	if err == nil {
+		t.Fatal("NewManager() expected error for missing required fields")
+	}
+	if got := config.MissingRequired(err, cfg); !reflect.DeepEqual(got, []string{"name", "ports[0].name"}) {
+		t.Errorf("MissingRequired() = %v, want [name ports[0].name]", got)
+	}
+	if !strings.Contains(err.Error(), "GENEVER_NAME (name)") {
+		t.Errorf("error = %v, want it to suggest GENEVER_NAME", err)
+	}
+	if strings.Contains(err.Error(), "GENEVER_PORTS") {
+		t.Errorf("error = %v, should not suggest a variable for a list element", err)
+	}
+}
+
+func TestEnvSource_LeafWinsOverNested(t *testing.T) {
+	originalEnv := os.Environ()
+	defer restoreEnv(originalEnv)
+
+	os.Clearenv()
+	os.Setenv("GENEVER_DB_HOST", "localhost")
+	os.Setenv("GENEVER_DB", "postgres://db")
+
+	result, err := (&EnvSource{}).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := result["db"]; got != "postgres://db" {
+		t.Errorf("db = %v, want leaf value", got)
+	}
+}
+
+func TestManager_EnvCLIShapeConflicts(t *testing.T) {
+	type AppConfig struct {
+		DB any `config:"db"`
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want any
+	}{
+		{
+			name: "env leaf then cli map",
+			env:  map[string]string{"GENEVER_DB": "postgres://db"},
+			args: []string{"--db.host=db.internal"},
+			want: map[string]any{"host": "db.internal"},
+		},
+		{
+			name: "env map then cli leaf",
+			env:  map[string]string{"GENEVER_DB_HOST": "db.internal"},
+			args: []string{"--db=postgres://db"},
+			want: "postgres://db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalEnv := os.Environ()
+			defer restoreEnv(originalEnv)
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Clearenv()
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			os.Args = append([]string{"test"}, tt.args...)
+
+			var cfg AppConfig
+			if _, err := config.NewManager(&cfg, config.Options{}, &EnvSource{}, &CLISource{}); err != nil {
+				t.Fatalf("NewManager() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.DB, tt.want) {
+				t.Errorf("DB = %#v, want %#v", cfg.DB, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvSource_Watch(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		if err := (&EnvSource{}).Watch(context.Background(), nil); err != nil {
+			t.Errorf("Watch() = %v, want nil", err)
+		}
+	})
+
+	t.Run("poll", func(t *testing.T) {
+		t.Setenv("GENEVER_WATCH_LEVEL", "info")
+
+		source := &EnvSource{PollInterval: 10 * time.Millisecond}
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan config.Event, 1)
+		done := make(chan error, 1)
+		go func() { done <- source.Watch(ctx, ch) }()
+
+		// Unchanged polls send nothing.
+		select {
+		case <-ch:
+			t.Fatal("got an event without a change")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		os.Setenv("GENEVER_WATCH_LEVEL", "debug")
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("no event after changing a variable")
+		}
+
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Watch() = %v, want context.Canceled", err)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/skekre98/genever/config"
+	"gopkg.in/yaml.v3"
+)
+
+// maxStderr caps how much of a failing command's stderr ends up in errors.
+const maxStderr = 1024
+
+// ExecSource loads configuration from the standard output of a command.
+//
+// Some deployments generate configuration dynamically through a helper
+// command (a secrets agent, a decryption wrapper). ExecSource runs Command
+// with Args, reads its stdout, and parses it as YAML (the default) or JSON
+// into the nested map.
+//
+// Usage:
+//
+//	source := &source.ExecSource{
+//	    Command: "/usr/local/bin/render-config",
+//	    Args:    []string{"--env", "prod"},
+//	    Format:  "json",
+//	    Timeout: 10 * time.Second,
+//	}
+//
+// Security:
+//   - The command runs with the privileges and environment of this process.
+//     Never build Command or Args from untrusted input.
+//   - Args are passed directly to the program, not through a shell, so they
+//     are not subject to shell expansion or injection.
+//   - On failure, the error includes the command's stderr (trimmed and capped
+//     at 1KB) to aid debugging. Commands that may print secrets to stderr
+//     should be wrapped to avoid leaking them into logs.
+type ExecSource struct {
+	// Command is the program to run, resolved via PATH if not absolute.
+	Command string
+
+	// Args are passed to Command as-is.
+	Args []string
+
+	// Format of the command's output: "yaml" (default) or "json".
+	Format string
+
+	// Timeout bounds each run. Zero means only the caller's context applies.
+	Timeout time.Duration
+
+	// Interval makes Watch re-run the command periodically and report
+	// changes. Zero disables watching.
+	Interval time.Duration
+}
+
+// Name returns the identifier for this source.
+func (e *ExecSource) Name() string { return "exec" }
+
+// Load runs the command and parses its output.
+//
+// The context (and Timeout, if set) bounds the run; the process is killed
+// when either expires.
+//
+// Returns an error including stderr if the command exits non-zero, or a
+// parse error if the output is malformed.
+func (e *ExecSource) Load(ctx context.Context) (map[string]any, error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return nil, fmt.Errorf("exec %s: %w: %s", e.Command, err, truncate(stderr.String(), maxStderr))
+	}
+
+	data := map[string]any{}
+	if err := parseConfig(stdout.Bytes(), e.Format, data); err != nil {
+		return nil, fmt.Errorf("exec %s: parse output: %w", e.Command, err)
+	}
+	return data, nil
+}
+
+// Watch re-runs the command every Interval and sends an Event when its
+// output changes. Failed runs are skipped; the next tick tries again.
+//
+// Returns nil immediately if Interval is zero, otherwise blocks until the
+// context is cancelled and returns ctx.Err().
+func (e *ExecSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	if e.Interval <= 0 {
+		return nil
+	}
+
+	last, _ := e.Load(ctx)
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			current, err := e.Load(ctx)
+			if err != nil || reflect.DeepEqual(current, last) {
+				continue
+			}
+			last = current
+			select {
+			case ch <- config.Event{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// parseConfig unmarshals data in the given format ("yaml" by default, or
+// "json") into out.
+func parseConfig(data []byte, format string, out map[string]any) error {
+	switch format {
+	case "", "yaml", "yml":
+		return yaml.Unmarshal(data, &out)
+	case "json":
+		return json.Unmarshal(data, &out)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
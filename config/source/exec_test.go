@@ -0,0 +1,131 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestExecSource_Name(t *testing.T) {
+	source := &ExecSource{}
+	expected := "exec"
+	if got := source.Name(); got != expected {
+		t.Errorf("Name() = %v, want %v", got, expected)
+	}
+}
+
+func TestExecSource_Load(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   *ExecSource
+		expected map[string]any
+	}{
+		{
+			name: "yaml output",
+			source: &ExecSource{
+				Command: "sh",
+				Args:    []string{"-c", `printf 'app:\n  name: test-app\n  port: 8080\n'`},
+			},
+			expected: map[string]any{
+				"app": map[string]any{"name": "test-app", "port": 8080},
+			},
+		},
+		{
+			name: "json output",
+			source: &ExecSource{
+				Command: "sh",
+				Args:    []string{"-c", `echo '{"database": {"host": "db.local"}}'`},
+				Format:  "json",
+			},
+			expected: map[string]any{
+				"database": map[string]any{"host": "db.local"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Load() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExecSource_Load_Errors(t *testing.T) {
+	t.Run("non-zero exit includes stderr", func(t *testing.T) {
+		source := &ExecSource{Command: "sh", Args: []string{"-c", "echo 'vault sealed' >&2; exit 3"}}
+		_, err := source.Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "vault sealed") {
+			t.Errorf("Load() error = %v, want error containing stderr", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		source := &ExecSource{Command: "sleep", Args: []string{"5"}, Timeout: 50 * time.Millisecond}
+		start := time.Now()
+		_, err := source.Load(context.Background())
+		if err == nil {
+			t.Fatal("Load() expected timeout error")
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Errorf("Load() took %v, timeout not honored", time.Since(start))
+		}
+	})
+
+	t.Run("malformed output", func(t *testing.T) {
+		source := &ExecSource{Command: "echo", Args: []string{"{not json"}, Format: "json"}
+		if _, err := source.Load(context.Background()); err == nil {
+			t.Error("Load() expected parse error")
+		}
+	})
+}
+
+func TestExecSource_Watch(t *testing.T) {
+	t.Run("disabled without interval", func(t *testing.T) {
+		source := &ExecSource{Command: "true"}
+		if err := source.Watch(context.Background(), make(chan config.Event)); err != nil {
+			t.Errorf("Watch() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("reports changed output", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.yaml")
+		if err := os.WriteFile(path, []byte("version: 1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		source := &ExecSource{Command: "cat", Args: []string{path}, Interval: 10 * time.Millisecond}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch := make(chan config.Event, 1)
+		errCh := make(chan error, 1)
+		go func() { errCh <- source.Watch(ctx, ch) }()
+
+		time.Sleep(30 * time.Millisecond)
+		if err := os.WriteFile(path, []byte("version: 2\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event after output changed")
+		}
+
+		cancel()
+		if err := <-errCh; err != context.Canceled {
+			t.Errorf("Watch() error = %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -1,25 +1,54 @@
 package source
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/skekre98/genever/config"
 	"gopkg.in/yaml.v3"
 )
 
-// FileSource loads configuration from YAML files on the filesystem.
+// DefaultExtensions are the file extensions FileSource tries, in order,
+// when Extensions is unset.
+var DefaultExtensions = []string{".yaml", ".yml", ".json", ".toml"}
+
+// FileSource loads configuration from YAML, JSON or TOML files on the
+// filesystem.
 //
 // FileSource supports a base configuration file and an optional profile-specific
-// overlay file. Both .yaml and .yml extensions are supported.
+// overlay file. The .yaml, .yml, .json and .toml extensions are supported,
+// and are tried in that order (see Extensions); the base and profile files
+// may use different formats. TOML tables decode to nested maps like YAML
+// mappings, and TOML dates and times to time.Time.
 //
 // File loading order:
-//  1. Load application.yaml (or application.yml) from BasePath
+//  1. Load application.yaml (or .yml, .json, .toml) from BasePath
 //  2. If Profile is set, load application.{profile}.yaml as an overlay
 //
 // The profile file's values override the base file's values at the top level.
 // Note: YAML unmarshaling replaces entire top-level keys rather than deep merging.
+// Set DeepMerge to merge nested keys instead.
+//
+// A profile can inherit from another with a top-level extends key, e.g.
+// application.prod-canary.yaml containing "extends: prod". The chain is
+// resolved root first and deep-merged, so each profile only lists what it
+// changes, and the result overlays the base file as a single profile:
+//
+//	application.prod.yaml         # extends: base
+//	application.prod-canary.yaml  # extends: prod
 //
 // Example directory structure:
 //
@@ -34,56 +63,349 @@ import (
 //	    BasePath: "configs",
 //	    Profile:  "prod",
 //	}
+//
+// BasePath may also name the base file itself, for setups with a single
+// file at an arbitrary path. Profiles are then read from its siblings
+// named after it: with BasePath "/etc/myapp.yaml" and Profile "prod",
+// /etc/myapp.prod.yaml overlays /etc/myapp.yaml.
 type FileSource struct {
 	// BasePath is the directory containing the configuration files.
 	// The base file (application.yaml) must exist in this directory.
+	// If BasePath is a file, it's the base file, whatever its name.
 	BasePath string
 
+	// BasePaths, if set, replaces BasePath with several directories, e.g.
+	// bundled defaults followed by an ops-managed directory:
+	//
+	//	BasePaths: []string{"./config", "/etc/app"}
+	//
+	// Each directory is loaded like BasePath (base file, then profile) and
+	// the results are deep-merged in order, so later directories override
+	// earlier ones. Directories without a base file are skipped; Load fails
+	// only if none has one. Entries may be files, as for BasePath.
+	BasePaths []string
+
 	// Profile specifies an optional configuration profile.
 	// If set, application.{Profile}.yaml will be loaded as an overlay.
-	// If the profile file doesn't exist, it's silently ignored.
+	// If the profile file doesn't exist, it's silently ignored; a profile
+	// named by extends must exist.
 	Profile string
+
+	// PreserveOrder additionally records the loaded document with keys in
+	// the order they were authored, available from Ordered. Useful for
+	// dumping the effective file config in a diff-friendly form.
+	PreserveOrder bool
+
+	// DeepMerge merges the profile into the base file key by key at every
+	// level (see config.MergeMaps), so a profile setting database.host
+	// keeps database.port from the base file. By default a profile replaces
+	// whole top-level keys.
+	DeepMerge bool
+
+	// Extensions lists the file extensions to try for each file, in order
+	// of preference; the first one found is used. Nil means
+	// DefaultExtensions. Extensions other than .json and .toml are read as
+	// YAML.
+	Extensions []string
+
+	// WatchDebounce is how long Watch waits after a change for further
+	// ones before sending a single Event. Zero means 100ms.
+	WatchDebounce time.Duration
+
+	// Strict surfaces problems that are otherwise tolerated: a profile file
+	// that fails to parse (e.g. a duplicated key) is an error instead of
+	// being skipped, and a file holding more than one YAML (or JSON) document is
+	// rejected instead of reading only the first. Duplicate keys in the
+	// base file are always an error.
+	Strict bool
+
+	mu      sync.Mutex
+	ordered *config.OrderedMap
 }
 
 // Name returns the identifier for this source.
 func (f *FileSource) Name() string { return "file" }
 
-// Load reads YAML configuration files from the filesystem.
+// Load reads YAML, JSON or TOML configuration files from the filesystem.
 //
-// Loads the base file (application.yaml or application.yml) and optionally
+// Loads the base file (application.yaml, .yml, .json or .toml) and optionally
 // overlays a profile-specific file if Profile is set.
 //
 // The context is currently not used but is included for future support of
 // cancellation and timeouts.
 //
-// Returns os.ErrNotExist if the base file is not found.
-// Returns a YAML parsing error if the files are malformed.
+// Returns os.ErrNotExist if no base file is found.
+// Returns a parsing error if the files are malformed.
 func (f *FileSource) Load(ctx context.Context) (map[string]any, error) {
-	// Try both .yaml and .yml extensions for the base file
-	baseFile := findYAMLFile(f.BasePath, "application")
-	if baseFile == "" {
+	var (
+		data  map[string]any
+		order *config.OrderedMap
+	)
+	for _, loc := range f.locations() {
+		dirData, dirOrder, err := f.loadDir(loc)
+		if err != nil {
+			return nil, err
+		}
+		if dirData == nil {
+			continue // no base file in dir
+		}
+		if data == nil {
+			data, order = dirData, dirOrder
+		} else {
+			config.MergeMaps(data, dirData)
+			mergeOrder(order, dirOrder, true)
+		}
+	}
+	if data == nil {
 		return nil, os.ErrNotExist
 	}
 
+	if f.PreserveOrder {
+		f.mu.Lock()
+		f.ordered = orderedValues(data, order)
+		f.mu.Unlock()
+	}
+
+	return data, nil
+}
+
+// location is where one of the source's paths keeps its files.
+type location struct {
+	dir  string // directory of the base and profile files
+	stem string // their name up to the profile: "application", or the named file's
+	file string // the base file, if the path named one
+}
+
+// locations resolves the source's paths: a file is the base file, with
+// profiles next to it; anything else is a directory of application.* files.
+func (f *FileSource) locations() []location {
+	var out []location
+	for _, path := range f.dirs() {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			name := filepath.Base(path)
+			out = append(out, location{
+				dir:  filepath.Dir(path),
+				stem: strings.TrimSuffix(name, filepath.Ext(name)),
+				file: path,
+			})
+			continue
+		}
+		out = append(out, location{dir: path, stem: "application"})
+	}
+	return out
+}
+
+// loadDir reads the base file of loc overlaid with the profile, with the
+// order of their keys if PreserveOrder is set. It returns nil data if loc
+// has no base file.
+func (f *FileSource) loadDir(loc location) (map[string]any, *config.OrderedMap, error) {
+	baseFile := loc.file
+	if baseFile == "" {
+		baseFile = f.findConfigFile(loc.dir, loc.stem)
+	}
+	if baseFile == "" {
+		return nil, nil, nil
+	}
+
 	data := map[string]any{}
-	if err := readYAML(baseFile, data); err != nil {
-		return nil, err
+	if err := readConfigFile(baseFile, data, f.Strict); err != nil {
+		return nil, nil, err
 	}
+	order := f.keyOrder(baseFile)
 
 	// Try to load profile-specific config if profile is set
-	if f.Profile != "" {
-		profileFile := findYAMLFile(f.BasePath, "application."+f.Profile)
-		if profileFile != "" {
-			_ = readYAML(profileFile, data)
+	profile, profileOrder, err := f.loadProfile(loc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.DeepMerge {
+		config.MergeMaps(data, profile)
+	} else {
+		for k, v := range profile {
+			data[k] = v
 		}
 	}
+	mergeOrder(order, profileOrder, f.DeepMerge)
+	return data, order, nil
+}
 
-	return data, nil
+// Ordered returns the document from the last Load with its original key
+// order, or nil if PreserveOrder is off or nothing has been loaded.
+//
+// Profile keys replace base keys at the top level in place; keys only in
+// the profile come after the base keys.
+func (f *FileSource) Ordered() *config.OrderedMap {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ordered
 }
 
-// findYAMLFile looks for a file with either .yaml or .yml extension
-func findYAMLFile(dir, basename string) string {
-	for _, ext := range []string{".yaml", ".yml"} {
+// extendsKey is the top-level key naming the profile a profile inherits from.
+const extendsKey = "extends"
+
+// loadProfile reads Profile and the profiles it extends from loc, returning
+// their deep-merged values without extends keys, and with PreserveOrder
+// the order of their keys.
+//
+// A missing or (unless Strict) unparsable Profile file yields no data, as
+// before profiles could extend each other. A parent that's missing or
+// doesn't parse, or a cycle, is always an error: dropping the parent would
+// load its child's values over the wrong base.
+func (f *FileSource) loadProfile(loc location) (map[string]any, *config.OrderedMap, error) {
+	var (
+		layers []map[string]any
+		orders []*config.OrderedMap
+		chain  []string
+	)
+	for name := f.Profile; name != ""; {
+		if slices.Contains(chain, name) {
+			return nil, nil, fmt.Errorf("profile extends cycle: %s", strings.Join(append(chain, name), " -> "))
+		}
+		path := f.findConfigFile(loc.dir, loc.stem+"."+name)
+		if path == "" {
+			if len(chain) == 0 {
+				return nil, nil, nil
+			}
+			return nil, nil, fmt.Errorf("profile %q extends missing profile %q", chain[len(chain)-1], name)
+		}
+		chain = append(chain, name)
+
+		m := map[string]any{}
+		if err := readConfigFile(path, m, f.Strict); err != nil {
+			if len(chain) > 1 {
+				return nil, nil, fmt.Errorf("profile %q extends unparsable profile %q: %w", chain[len(chain)-2], name, err)
+			}
+			if f.Strict {
+				return nil, nil, err
+			}
+			return nil, nil, nil
+		}
+		parent, ok := m[extendsKey].(string)
+		if _, set := m[extendsKey]; set && !ok {
+			return nil, nil, fmt.Errorf("%s: %s must name a profile", path, extendsKey)
+		}
+		delete(m, extendsKey)
+		layers = append([]map[string]any{m}, layers...)
+		orders = append([]*config.OrderedMap{f.keyOrder(path)}, orders...)
+		name = parent
+	}
+
+	merged := map[string]any{}
+	var order *config.OrderedMap
+	if f.PreserveOrder {
+		order = &config.OrderedMap{}
+	}
+	for i, m := range layers {
+		config.MergeMaps(merged, m)
+		mergeOrder(order, orders[i], true)
+	}
+	return merged, order, nil
+}
+
+// keyOrder reads the keys of the file at path in the order they're
+// written, or returns nil if PreserveOrder is off. Only the keys are used:
+// the values Ordered returns are those Load read, so the two agree.
+func (f *FileSource) keyOrder(path string) *config.OrderedMap {
+	if !f.PreserveOrder {
+		return nil
+	}
+	order := &config.OrderedMap{}
+	b, err := os.ReadFile(path)
+	if err != nil || len(bytes.TrimSpace(b)) == 0 {
+		return order
+	}
+	// Load has parsed the file already; keys a failure here leaves out
+	// are placed after the others by orderedValues.
+	if isTOML(path) {
+		_ = orderedTOML(b, order)
+	} else {
+		_ = yaml.Unmarshal(b, order)
+	}
+	return order
+}
+
+// mergeOrder adds the keys of src to dst the way Load combines the files
+// they came from: with deep at every level, else replacing dst's top-level
+// keys. It does nothing if either is nil.
+func mergeOrder(dst, src *config.OrderedMap, deep bool) {
+	if dst == nil || src == nil {
+		return
+	}
+	for _, k := range src.Keys() {
+		v, _ := src.Get(k)
+		if deep {
+			setMerged(dst, k, v)
+		} else {
+			dst.Set(k, v)
+		}
+	}
+}
+
+// orderedValues returns data as an OrderedMap, its keys ordered as in
+// order at every level. Keys order doesn't have follow, sorted.
+func orderedValues(data map[string]any, order *config.OrderedMap) *config.OrderedMap {
+	if order == nil {
+		order = &config.OrderedMap{}
+	}
+	out := &config.OrderedMap{}
+	seen := map[string]bool{}
+	for _, k := range order.Keys() {
+		if v, ok := data[k]; ok && !seen[k] {
+			o, _ := order.Get(k)
+			out.Set(k, orderedValue(v, o))
+			seen[k] = true
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		if !seen[k] {
+			out.Set(k, orderedValue(data[k], nil))
+		}
+	}
+	return out
+}
+
+// orderedValue converts the maps in v, as in orderedValues, taking their
+// key order from the corresponding part of order.
+func orderedValue(v, order any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		o, _ := order.(*config.OrderedMap)
+		return orderedValues(v, o)
+	case []any:
+		items, _ := order.([]any)
+		out := make([]any, len(v))
+		for i, e := range v {
+			var o any
+			if i < len(items) {
+				o = items[i]
+			}
+			out[i] = orderedValue(e, o)
+		}
+		return out
+	}
+	return v
+}
+
+// setMerged sets k to v in o, merging into the existing value where both
+// are mappings.
+func setMerged(o *config.OrderedMap, k string, v any) {
+	dst, _ := o.Get(k)
+	dstMap, ok1 := dst.(*config.OrderedMap)
+	srcMap, ok2 := v.(*config.OrderedMap)
+	if !ok1 || !ok2 {
+		o.Set(k, v)
+		return
+	}
+	for _, nk := range srcMap.Keys() {
+		nv, _ := srcMap.Get(nk)
+		setMerged(dstMap, nk, nv)
+	}
+}
+
+// findConfigFile looks for basename in dir with each of the source's
+// extensions in order, returning the first path found.
+func (f *FileSource) findConfigFile(dir, basename string) string {
+	for _, ext := range f.extensions() {
 		path := filepath.Join(dir, basename+ext)
 		if _, err := os.Stat(path); err == nil {
 			return path
@@ -92,17 +414,257 @@ func findYAMLFile(dir, basename string) string {
 	return ""
 }
 
-// Watch is not implemented for FileSource.
-// Returns nil immediately, indicating that file watching is not supported.
+// Watch watches the config directories and sends an Event when an
+// application.* file (or, for a BasePath naming a file, a file named like
+// it) with one of the source's extensions is written, created, renamed or
+// removed. Directories rather than files are watched, so editors that save
+// by replacing the file are seen too.
+//
+// Changes within WatchDebounce of each other produce a single Event, as
+// editors often write a file more than once per save.
 //
-// To enable automatic reloading when files change, consider using a file
-// watcher library like fsnotify and implementing Watch accordingly.
-func (f *FileSource) Watch(ctx context.Context, ch chan<- config.Event) error { return nil }
+// Blocks until the context is cancelled and returns ctx.Err(), or returns
+// an error if the watcher can't be set up.
+func (f *FileSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("file source: watch: %w", err)
+	}
+	defer w.Close()
+	locs := f.locations()
+	for _, loc := range locs {
+		// Load skips missing directories among BasePaths; so does Watch.
+		if err := w.Add(loc.dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("file source: watch %s: %w", loc.dir, err)
+		}
+	}
+	if len(w.WatchList()) == 0 {
+		return fmt.Errorf("file source: watch: no directory of %v exists", f.dirs())
+	}
+
+	debounce := f.WatchDebounce
+	if debounce <= 0 {
+		debounce = defaultWatchDebounce
+	}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if f.watched(ev, locs) {
+				timer.Reset(debounce)
+			}
+		case <-w.Errors:
+			// Usually a dropped event (queue overflow); the next change
+			// still triggers a reload.
+		case <-timer.C:
+			select {
+			case ch <- config.Event{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// defaultWatchDebounce is used when WatchDebounce is zero.
+const defaultWatchDebounce = 100 * time.Millisecond
+
+// watched reports whether ev changes a config file of one of locs.
+func (f *FileSource) watched(ev fsnotify.Event, locs []location) bool {
+	if !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Rename) && !ev.Has(fsnotify.Remove) {
+		return false
+	}
+	dir, name := filepath.Split(ev.Name)
+	if !slices.ContainsFunc(locs, func(loc location) bool {
+		return filepath.Clean(dir) == filepath.Clean(loc.dir) && strings.HasPrefix(name, loc.stem+".")
+	}) {
+		return false
+	}
+	ext := filepath.Ext(name)
+	return slices.ContainsFunc(f.extensions(), func(e string) bool { return strings.EqualFold(e, ext) })
+}
+
+// dirs returns the directories to load, BasePaths or else BasePath.
+func (f *FileSource) dirs() []string {
+	if len(f.BasePaths) > 0 {
+		return f.BasePaths
+	}
+	return []string{f.BasePath}
+}
+
+// extensions returns Extensions, or DefaultExtensions if it's unset.
+func (f *FileSource) extensions() []string {
+	if f.Extensions != nil {
+		return f.Extensions
+	}
+	return DefaultExtensions
+}
+
+// readConfigFile reads the file at path into out, as JSON or TOML by its
+// extension and as YAML otherwise.
+func readConfigFile(path string, out map[string]any, strict bool) error {
+	switch {
+	case strings.EqualFold(filepath.Ext(path), ".json"):
+		return readJSON(path, out, strict)
+	case isTOML(path):
+		return readTOML(path, out)
+	}
+	return readYAML(path, out, strict)
+}
+
+func isTOML(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
+
+// readTOML unmarshals the TOML document in the file at path into out, with
+// the same handling of empty files as readYAML. TOML has a single document
+// per file and rejects duplicate keys, so there's no strict mode to apply.
+func readTOML(path string, out map[string]any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for k, v := range doc {
+		out[k] = tomlValue(v)
+	}
+	return nil
+}
+
+// tomlValue converts the int64s in v to int, as YAML decodes integers, and
+// TOML's local date and time types to time.Time.
+func tomlValue(v any) any {
+	switch v := v.(type) {
+	case int64:
+		return int(v)
+	case toml.LocalDate:
+		return v.AsTime(time.UTC)
+	case toml.LocalDateTime:
+		return v.AsTime(time.UTC)
+	case map[string]any:
+		for k, e := range v {
+			v[k] = tomlValue(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = tomlValue(e)
+		}
+		return v
+	}
+	return v
+}
 
-func readYAML(path string, out map[string]any) error {
+// orderedTOML decodes a TOML document into doc. The TOML decoder doesn't
+// report key order, so keys are recorded sorted at every level.
+func orderedTOML(b []byte, doc *config.OrderedMap) error {
+	var m map[string]any
+	if err := toml.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	fillOrdered(doc, m)
+	return nil
+}
+
+func fillOrdered(doc *config.OrderedMap, m map[string]any) {
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		v := tomlValue(m[k])
+		if nested, ok := v.(map[string]any); ok {
+			child := &config.OrderedMap{}
+			fillOrdered(child, nested)
+			v = child
+		}
+		doc.Set(k, v)
+	}
+}
+
+// readJSON unmarshals the JSON object in the file at path into out, with
+// the same handling of empty files and strict mode as readYAML. Numbers
+// become int when integral and float64 otherwise, as YAML decodes them.
+func readJSON(path string, out map[string]any, strict bool) error {
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return err
 	}
-	return yaml.Unmarshal(b, &out)
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if strict && dec.More() {
+		return fmt.Errorf("%s: multiple JSON documents", path)
+	}
+	for k, v := range doc {
+		out[k] = jsonValue(v)
+	}
+	return nil
+}
+
+// jsonValue converts the json.Numbers in v to int or float64.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v {
+			v[k] = jsonValue(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = jsonValue(e)
+		}
+		return v
+	}
+	return v
+}
+
+// readYAML unmarshals the file at path into out. An empty or
+// whitespace-only file leaves out untouched, so an empty overlay is a no-op.
+// With strict, a file with more than one document is an error.
+func readYAML(path string, out map[string]any, strict bool) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	if !strict {
+		return yaml.Unmarshal(b, &out)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	if err := dec.Decode(&out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); err != io.EOF {
+		return fmt.Errorf("%s: multiple YAML documents", path)
+	}
+	return nil
 }
@@ -2,10 +2,17 @@ package source
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+	"gopkg.in/yaml.v3"
 )
 
 func TestFileSource_Name(t *testing.T) {
@@ -368,15 +375,246 @@ cache:
 	}
 }
 
+func TestFileSource_Load_DeepMerge(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	baseContent := `
+database:
+  host: localhost
+  port: 5432
+  pool:
+    min: 5
+    max: 10
+`
+	profContent := `
+database:
+  host: prod-db.example.com
+  pool:
+    max: 50
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "application.yaml"), []byte(baseContent), 0644); err != nil {
+		t.Fatalf("Failed to write base file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "application.prod.yaml"), []byte(profContent), 0644); err != nil {
+		t.Fatalf("Failed to write profile file: %v", err)
+	}
+
+	source := &FileSource{BasePath: tmpDir, Profile: "prod", DeepMerge: true, PreserveOrder: true}
+	result, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"database": map[string]any{
+			"host": "prod-db.example.com",
+			"port": 5432,
+			"pool": map[string]any{"min": 5, "max": 50},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+	if ordered := source.Ordered().Map(); !reflect.DeepEqual(ordered, expected) {
+		t.Errorf("Ordered() = %v, want %v", ordered, expected)
+	}
+}
+
+func TestFileSource_Load_FilePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"myapp.yaml":       "server:\n  port: 8080\nname: orders\n",
+		"myapp.prod.yaml":  "server:\n  port: 9090\n",
+		"application.yaml": "name: ignored\n",
+		"other.prod.yaml":  "name: ignored\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	path := filepath.Join(tmpDir, "myapp.yaml")
+
+	tests := []struct {
+		name    string
+		profile string
+		want    map[string]any
+	}{
+		{
+			name: "base file only",
+			want: map[string]any{"server": map[string]any{"port": 8080}, "name": "orders"},
+		},
+		{
+			name:    "profile sibling",
+			profile: "prod",
+			want:    map[string]any{"server": map[string]any{"port": 9090}, "name": "orders"},
+		},
+		{
+			name:    "missing profile sibling",
+			profile: "dev",
+			want:    map[string]any{"server": map[string]any{"port": 8080}, "name": "orders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &FileSource{BasePath: path, Profile: tt.profile}
+			got, err := source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSource_Load_EmptyProfile(t *testing.T) {
+	baseContent := `
+app:
+  name: base-app
+  port: 8080
+`
+	expected := map[string]any{
+		"app": map[string]any{"name": "base-app", "port": 8080},
+	}
+
+	for name, profContent := range map[string]string{
+		"empty":           "",
+		"whitespace only": "  \n\t\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "application.yaml"), []byte(baseContent), 0644); err != nil {
+				t.Fatalf("Failed to write base file: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, "application.dev.yaml"), []byte(profContent), 0644); err != nil {
+				t.Fatalf("Failed to write profile file: %v", err)
+			}
+
+			source := &FileSource{BasePath: tmpDir, Profile: "dev", PreserveOrder: true}
+			result, err := source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Load() = %v, want %v", result, expected)
+			}
+			if keys := source.Ordered().Keys(); !reflect.DeepEqual(keys, []string{"app"}) {
+				t.Errorf("Ordered().Keys() = %v, want [app]", keys)
+			}
+		})
+	}
+}
+
+func TestFileSource_Load_PreserveOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := `server:
+  port: 8080
+  addr: localhost
+app:
+  name: test-app
+database:
+  host: localhost
+`
+	profile := `zcache:
+  ttl: 5m
+app:
+  version: 2.0.0
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "application.yaml"), []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "application.dev.yaml"), []byte(profile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &FileSource{BasePath: tmpDir, Profile: "dev", PreserveOrder: true}
+	if source.Ordered() != nil {
+		t.Error("Ordered() before Load should be nil")
+	}
+	data, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// The ordered document matches what Load returned.
+	if !reflect.DeepEqual(source.Ordered().Map(), data) {
+		t.Errorf("Ordered().Map() = %v, want %v", source.Ordered().Map(), data)
+	}
+
+	var dumped strings.Builder
+	enc := yaml.NewEncoder(&dumped)
+	enc.SetIndent(2)
+	if err := enc.Encode(source.Ordered()); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := `server:
+  port: 8080
+  addr: localhost
+app:
+  version: 2.0.0
+database:
+  host: localhost
+zcache:
+  ttl: 5m
+`
+	if dumped.String() != want {
+		t.Errorf("dumped config =\n%s\nwant\n%s", dumped.String(), want)
+	}
+}
+
 func TestFileSource_Watch(t *testing.T) {
-	source := &FileSource{
-		BasePath: "/tmp",
-		Profile:  "dev",
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "application.yaml")
+	if err := os.WriteFile(base, []byte("name: v1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write base file: %v", err)
+	}
+
+	source := &FileSource{BasePath: tmpDir, WatchDebounce: 50 * time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan config.Event, 10)
+	done := make(chan error, 1)
+	go func() { done <- source.Watch(ctx, ch) }()
+	time.Sleep(50 * time.Millisecond) // let the watcher start
+
+	// Unrelated files don't trigger events.
+	if err := os.WriteFile(filepath.Join(tmpDir, "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	// Several quick writes, as an editor saving, are one event.
+	for _, content := range []string{"name: v2\n", "name: v3\n", "name: v4\n"} {
+		if err := os.WriteFile(base, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write base file: %v", err)
+		}
 	}
 
-	// Watch should return nil (no-op for file source)
-	if err := source.Watch(context.Background(), nil); err != nil {
-		t.Errorf("Watch() returned error: %v", err)
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event after writing the config file")
+	}
+	select {
+	case <-ch:
+		t.Error("got a second event, want quick writes debounced into one")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Watch() = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch() didn't return after cancel")
+	}
+}
+
+func TestFileSource_Watch_MissingDir(t *testing.T) {
+	source := &FileSource{BasePath: filepath.Join(t.TempDir(), "missing")}
+	if err := source.Watch(context.Background(), make(chan config.Event)); err == nil {
+		t.Error("Watch() = nil, want error for a missing directory")
 	}
 }
 
@@ -395,3 +633,368 @@ func getNestedValueAny(m map[string]any, path []string) any {
 	}
 	return nil
 }
+
+func TestFileSource_Load_Strict(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	base := "server:\n  port: 8080\n"
+	duplicated := "server:\n  port: 9090\n  port: 9091\n"
+
+	t.Run("duplicate key in profile is tolerated when not strict", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", base)
+		write(t, tmpDir, "application.dev.yaml", duplicated)
+
+		result, err := (&FileSource{BasePath: tmpDir, Profile: "dev"}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := getNestedValueAny(result, []string{"server", "port"}); got != 8080 {
+			t.Errorf("server.port = %v, want base value", got)
+		}
+	})
+
+	t.Run("duplicate key in profile is an error when strict", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", base)
+		write(t, tmpDir, "application.dev.yaml", duplicated)
+
+		_, err := (&FileSource{BasePath: tmpDir, Profile: "dev", Strict: true}).Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "already defined") {
+			t.Errorf("Load() error = %v, want duplicate key error", err)
+		}
+	})
+
+	t.Run("duplicate key in base is always an error", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", duplicated)
+
+		if _, err := (&FileSource{BasePath: tmpDir}).Load(context.Background()); err == nil {
+			t.Error("Load() with duplicate key: expected error")
+		}
+	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", base+"---\nserver:\n  port: 9090\n")
+
+		if _, err := (&FileSource{BasePath: tmpDir}).Load(context.Background()); err != nil {
+			t.Errorf("Load() error = %v, want first document only", err)
+		}
+		if _, err := (&FileSource{BasePath: tmpDir, Strict: true}).Load(context.Background()); err == nil {
+			t.Error("strict Load() with multiple documents: expected error")
+		}
+	})
+}
+
+func TestFileSource_Load_ProfileExtends(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("two-level chain", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", "app:\n  name: orders\n")
+		write(t, tmpDir, "application.base.yaml", "database:\n  host: db.internal\n  pool:\n    min: 5\n    max: 10\n")
+		write(t, tmpDir, "application.prod.yaml", "extends: base\ndatabase:\n  pool:\n    max: 50\n")
+		write(t, tmpDir, "application.prod-canary.yaml", "extends: prod\ndatabase:\n  host: canary-db.internal\n")
+
+		source := &FileSource{BasePath: tmpDir, Profile: "prod-canary", PreserveOrder: true}
+		result, err := source.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		expected := map[string]any{
+			"app": map[string]any{"name": "orders"},
+			"database": map[string]any{
+				"host": "canary-db.internal",
+				"pool": map[string]any{"min": 5, "max": 50},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Load() = %v, want %v", result, expected)
+		}
+		if _, ok := source.Ordered().Get("extends"); ok {
+			t.Error("Ordered() contains the extends key")
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", "app:\n  name: orders\n")
+		write(t, tmpDir, "application.a.yaml", "extends: b\n")
+		write(t, tmpDir, "application.b.yaml", "extends: a\n")
+
+		_, err := (&FileSource{BasePath: tmpDir, Profile: "a"}).Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+			t.Errorf("Load() error = %v, want extends cycle", err)
+		}
+	})
+
+	t.Run("unparsable parent", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", "app:\n  name: orders\n")
+		write(t, tmpDir, "application.base.yaml", "database:\n  host: db.internal\n")
+		write(t, tmpDir, "application.prod.yaml", "extends: base\ndatabase: [unclosed\n")
+		write(t, tmpDir, "application.prod-canary.yaml", "extends: prod\napp:\n  name: canary\n")
+
+		_, err := (&FileSource{BasePath: tmpDir, Profile: "prod-canary"}).Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), `unparsable profile "prod"`) {
+			t.Errorf("Load() error = %v, want the parse error of the middle profile", err)
+		}
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", "app:\n  name: orders\n")
+		write(t, tmpDir, "application.prod.yaml", "extends: nope\n")
+
+		_, err := (&FileSource{BasePath: tmpDir, Profile: "prod"}).Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), `missing profile "nope"`) {
+			t.Errorf("Load() error = %v, want missing profile error", err)
+		}
+	})
+}
+
+func TestFileSource_Load_BasePaths(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	bundled, ops := t.TempDir(), t.TempDir()
+	write(t, bundled, "application.yaml", "app:\n  name: orders\nserver:\n  addr: \":8080\"\n  readTimeout: 5s\n")
+	write(t, bundled, "application.prod.yaml", "server:\n  addr: \":80\"\n  readTimeout: 10s\n")
+	write(t, ops, "application.yaml", "server:\n  readTimeout: 30s\n")
+
+	missing := filepath.Join(t.TempDir(), "nope")
+	source := &FileSource{BasePaths: []string{bundled, missing, ops}, Profile: "prod"}
+	result, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"app":    map[string]any{"name": "orders"},
+		"server": map[string]any{"addr": ":80", "readTimeout": "30s"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+
+	t.Run("all missing", func(t *testing.T) {
+		source := &FileSource{BasePaths: []string{missing, t.TempDir()}}
+		if _, err := source.Load(context.Background()); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Load() error = %v, want os.ErrNotExist", err)
+		}
+	})
+}
+
+func TestFileSource_Load_PreserveOrderMatchesLoad(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	bundled, ops := t.TempDir(), t.TempDir()
+	write(t, bundled, "application.yaml", "server:\n  port: 8080\n  addr: localhost\ndatabase:\n  host: localhost\n  pool:\n    min: 1\n")
+	write(t, bundled, "application.base.yaml", "database:\n  pool:\n    min: 5\n    max: 10\n")
+	write(t, bundled, "application.prod.yaml", "extends: base\ndatabase:\n  host: db.internal\n")
+	write(t, ops, "application.yaml", "server:\n  port: 9090\n")
+	write(t, ops, "application.prod.yaml", "extends: base\nzcache:\n  ttl: 5m\n")
+	write(t, ops, "application.base.yaml", "database:\n  pool:\n    max: 50\n")
+
+	for _, deep := range []bool{false, true} {
+		t.Run(fmt.Sprintf("DeepMerge=%v", deep), func(t *testing.T) {
+			source := &FileSource{BasePaths: []string{bundled, ops}, Profile: "prod", DeepMerge: deep, PreserveOrder: true}
+			data, err := source.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := source.Ordered().Map(); !reflect.DeepEqual(got, data) {
+				t.Errorf("Ordered().Map() = %v, want what Load returned: %v", got, data)
+			}
+			if keys := source.Ordered().Keys(); !reflect.DeepEqual(keys, []string{"server", "database", "zcache"}) {
+				t.Errorf("Ordered().Keys() = %v, want [server database zcache]", keys)
+			}
+		})
+	}
+}
+
+func TestFileSource_Load_JSON(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("base and profile", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.json", `{"app": {"name": "orders", "ratio": 0.5}, "server": {"port": 8080}}`)
+		write(t, tmpDir, "application.prod.json", `{"server": {"port": 80, "tags": ["a", "b"]}}`)
+
+		result, err := (&FileSource{BasePath: tmpDir, Profile: "prod"}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		expected := map[string]any{
+			"app":    map[string]any{"name": "orders", "ratio": 0.5},
+			"server": map[string]any{"port": 80, "tags": []any{"a", "b"}},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Load() = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("yaml base with json profile", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", "app:\n  name: orders\nserver:\n  port: 8080\n  host: localhost\n")
+		write(t, tmpDir, "application.dev.json", `{"server": {"port": 9090}}`)
+
+		result, err := (&FileSource{BasePath: tmpDir, Profile: "dev"}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		// Profiles replace top-level keys, whatever their format.
+		expected := map[string]any{
+			"app":    map[string]any{"name": "orders"},
+			"server": map[string]any{"port": 9090},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Load() = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.json", `{"app": {"name": "orders",}}`)
+
+		_, err := (&FileSource{BasePath: tmpDir, Profile: "dev"}).Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "application.json") {
+			t.Errorf("Load() error = %v, want JSON syntax error naming the file", err)
+		}
+	})
+}
+
+func TestFileSource_Load_TOML(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("base and profile", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.toml", `
+[app]
+name = "orders"
+ratio = 0.5
+
+[server]
+port = 8080
+timeout = "30s"
+`)
+		write(t, tmpDir, "application.prod.toml", `
+[server]
+port = 80
+tags = ["a", "b"]
+
+[server.tls]
+enabled = true
+`)
+
+		result, err := (&FileSource{BasePath: tmpDir, Profile: "prod"}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		expected := map[string]any{
+			"app": map[string]any{"name": "orders", "ratio": 0.5},
+			"server": map[string]any{
+				"port": 80,
+				"tags": []any{"a", "b"},
+				"tls":  map[string]any{"enabled": true},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Load() = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("missing profile is ignored", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.toml", "name = \"orders\"\n")
+
+		result, err := (&FileSource{BasePath: tmpDir, Profile: "dev"}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if result["name"] != "orders" {
+			t.Errorf("Load() = %v, want base values", result)
+		}
+	})
+
+	t.Run("extensions order", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.yaml", "name: yaml\n")
+		write(t, tmpDir, "application.toml", "name = \"toml\"\n")
+
+		for _, tt := range []struct {
+			exts []string
+			want string
+		}{
+			{nil, "yaml"},
+			{[]string{".toml", ".yaml"}, "toml"},
+		} {
+			result, err := (&FileSource{BasePath: tmpDir, Extensions: tt.exts}).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if result["name"] != tt.want {
+				t.Errorf("Extensions %v: name = %v, want %s", tt.exts, result["name"], tt.want)
+			}
+		}
+	})
+
+	t.Run("binds", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.toml", "[server]\nport = 8080\ntimeout = \"30s\"\n")
+
+		type AppConfig struct {
+			Server struct {
+				Port    int           `config:"port"`
+				Timeout time.Duration `config:"timeout"`
+			} `config:"server"`
+		}
+		var cfg AppConfig
+		if _, err := config.NewManager(&cfg, config.Options{}, &FileSource{BasePath: tmpDir}); err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		if cfg.Server.Port != 8080 || cfg.Server.Timeout != 30*time.Second {
+			t.Errorf("Server = %+v, want port 8080 and 30s timeout", cfg.Server)
+		}
+	})
+
+	t.Run("invalid toml", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		write(t, tmpDir, "application.toml", "name = \n")
+
+		_, err := (&FileSource{BasePath: tmpDir}).Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "application.toml") {
+			t.Errorf("Load() error = %v, want TOML syntax error naming the file", err)
+		}
+	})
+}
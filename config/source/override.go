@@ -0,0 +1,123 @@
+package source
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/skekre98/genever/config"
+)
+
+// Override is a ConfigSource holding programmatic overrides that can be
+// changed at runtime.
+//
+// Placed last in the source list, Override beats every other source, which
+// makes it useful for tests and for emergency runtime overrides:
+//
+//	overrides := &source.Override{}
+//	mgr, err := config.NewManager(&cfg, config.Options{AutoReload: true},
+//	    &source.FileSource{BasePath: "configs"},
+//	    &source.EnvSource{},
+//	    overrides,
+//	)
+//	...
+//	overrides.Set("server.readTimeout", "30s") // picked up by AutoReload
+//
+// Keys use dot notation for nesting, like CLISource flags. The zero value is
+// ready to use and all methods are safe for concurrent use.
+type Override struct {
+	mu       sync.RWMutex
+	values   map[string]any
+	watchers map[chan string]struct{}
+}
+
+// Name returns the identifier for this source.
+func (o *Override) Name() string { return "override" }
+
+// Set stores value at the dotted key path, replacing any previous value, and
+// notifies active watchers.
+func (o *Override) Set(key string, value any) {
+	o.mu.Lock()
+	if o.values == nil {
+		o.values = make(map[string]any)
+	}
+	current := o.values
+	segments := strings.Split(key, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		nested, ok := current[segment].(map[string]any)
+		if !ok {
+			// Overrides win: a leaf in the way is replaced by a map.
+			nested = make(map[string]any)
+			current[segment] = nested
+		}
+		current = nested
+	}
+	current[segments[len(segments)-1]] = value
+	watchers := make([]chan string, 0, len(o.watchers))
+	for ch := range o.watchers {
+		watchers = append(watchers, ch)
+	}
+	o.mu.Unlock()
+
+	for _, ch := range watchers {
+		select {
+		case ch <- key:
+		default:
+			// A notification is already pending; the reload it triggers
+			// will see this value too.
+		}
+	}
+}
+
+// Load returns a copy of the current overrides.
+func (o *Override) Load(ctx context.Context) (map[string]any, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return copyMap(o.values), nil
+}
+
+// Watch sends an Event on ch each time Set is called, until ctx is cancelled.
+//
+// Returns ctx.Err() when the context is cancelled.
+func (o *Override) Watch(ctx context.Context, ch chan<- config.Event) error {
+	notify := make(chan string, 1)
+
+	o.mu.Lock()
+	if o.watchers == nil {
+		o.watchers = make(map[chan string]struct{})
+	}
+	o.watchers[notify] = struct{}{}
+	o.mu.Unlock()
+
+	defer func() {
+		o.mu.Lock()
+		delete(o.watchers, notify)
+		o.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key := <-notify:
+			select {
+			case ch <- config.Event{ChangedKeys: []string{key}}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// copyMap returns a deep copy of the nested maps in m.
+func copyMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok {
+			out[k] = copyMap(nested)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package source
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestOverride_Name(t *testing.T) {
+	source := &Override{}
+	expected := "override"
+	if got := source.Name(); got != expected {
+		t.Errorf("Name() = %v, want %v", got, expected)
+	}
+}
+
+func TestOverride_SetAndLoad(t *testing.T) {
+	source := &Override{}
+
+	empty, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("Load() on zero Override = %v, want empty map", empty)
+	}
+
+	source.Set("server.port", 9090)
+	source.Set("server.host", "override.local")
+	source.Set("debug", true)
+
+	got, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := map[string]any{
+		"server": map[string]any{"port": 9090, "host": "override.local"},
+		"debug":  true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %v, want %v", got, want)
+	}
+
+	// Mutating the loaded map must not affect the source.
+	got["server"].(map[string]any)["port"] = 1
+	again, _ := source.Load(context.Background())
+	if again["server"].(map[string]any)["port"] != 9090 {
+		t.Error("Load() should return a copy of the overrides")
+	}
+
+	// Setting a nested key under an existing leaf replaces the leaf.
+	source.Set("debug.level", "trace")
+	got, _ = source.Load(context.Background())
+	if !reflect.DeepEqual(got["debug"], map[string]any{"level": "trace"}) {
+		t.Errorf("debug = %v, want nested map", got["debug"])
+	}
+}
+
+func TestOverride_Watch(t *testing.T) {
+	source := &Override{}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan config.Event)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- source.Watch(ctx, ch) }()
+
+	// Set until the watcher has registered and forwarded an event.
+	deadline := time.After(2 * time.Second)
+	for received := false; !received; {
+		source.Set("server.port", 9090)
+		select {
+		case evt := <-ch:
+			if !reflect.DeepEqual(evt.ChangedKeys, []string{"server.port"}) {
+				t.Errorf("ChangedKeys = %v, want [server.port]", evt.ChangedKeys)
+			}
+			received = true
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("no event received after Set")
+		}
+	}
+
+	cancel()
+	if err := <-errCh; err != context.Canceled {
+		t.Errorf("Watch() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestOverride_AutoReload(t *testing.T) {
+	type AppConfig struct {
+		Name string `config:"name" validate:"required"`
+		Port int    `config:"port" validate:"required"`
+	}
+
+	base := &Override{}
+	base.Set("name", "app")
+	base.Set("port", 8080)
+	overrides := &Override{}
+
+	var cfg AppConfig
+	mgr, err := config.NewManager(&cfg, config.Options{AutoReload: true}, base, overrides)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	events := make(chan config.Event, 10)
+	mgr.Subscribe(events)
+
+	// Keep forcing the value until the watcher is up and the reload lands.
+	deadline := time.After(2 * time.Second)
+	for {
+		overrides.Set("port", 9090)
+		select {
+		case evt := <-events:
+			newCfg := evt.NewConfig.(*AppConfig)
+			if newCfg.Port != 9090 || newCfg.Name != "app" {
+				t.Errorf("reloaded config = %+v, want Name=app Port=9090", newCfg)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("override was not picked up by AutoReload")
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skekre98/genever/config"
+)
+
+// SOURCES_ENV names the environment variable SourcesFromEnv reads. EnvSource
+// skips it, so it doesn't show up as a "sources" config key.
+const SOURCES_ENV = ENV_PREFIX + "SOURCES"
+
+// defaultSources is used when SOURCES_ENV is unset or empty.
+const defaultSources = "file,env,cli"
+
+// SourcesFromEnv builds the source list named by GENEVER_SOURCES, a
+// comma-separated list in precedence order (later sources win):
+//
+//	GENEVER_SOURCES=file,env        # local
+//	GENEVER_SOURCES=file,env,cli    # default when unset
+//
+// This lets one binary switch config backends per environment. Each source
+// is constructed with defaults:
+//   - file: FileSource with BasePath from CONFIG_PATH (default "configs")
+//     and Profile from APP_PROFILE
+//   - dotenv: DotEnvSource reading .env, skipped if it doesn't exist
+//   - env: EnvSource
+//   - cli: CLISource
+//   - override: an empty Override, to Set values on; list it last so it
+//     wins
+//
+// Callers can adjust the returned sources (e.g. a FileSource's BasePath)
+// before passing them to config.NewManager.
+//
+// Returns an error naming the first unknown source.
+func SourcesFromEnv() ([]config.ConfigSource, error) {
+	spec := strings.TrimSpace(os.Getenv(SOURCES_ENV))
+	if spec == "" {
+		spec = defaultSources
+	}
+
+	var sources []config.ConfigSource
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case "file":
+			basePath := os.Getenv("CONFIG_PATH")
+			if basePath == "" {
+				basePath = "configs"
+			}
+			sources = append(sources, &FileSource{BasePath: basePath, Profile: os.Getenv("APP_PROFILE")})
+		case "dotenv":
+			sources = append(sources, &DotEnvSource{Path: ".env", Optional: true})
+		case "env":
+			sources = append(sources, &EnvSource{})
+		case "cli":
+			sources = append(sources, &CLISource{})
+		case "override":
+			sources = append(sources, &Override{})
+		default:
+			return nil, fmt.Errorf("%s: unknown source %q", SOURCES_ENV, name)
+		}
+	}
+	return sources, nil
+}
@@ -0,0 +1,94 @@
+package source
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSourcesFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		types []string
+	}{
+		{"default", "", []string{"*source.FileSource", "*source.EnvSource", "*source.CLISource"}},
+		{"file and env", "file,env", []string{"*source.FileSource", "*source.EnvSource"}},
+		{"spaces and case", " Env , override ", []string{"*source.EnvSource", "*source.Override"}},
+		{"dotenv", "file,dotenv,env", []string{"*source.FileSource", "*source.DotEnvSource", "*source.EnvSource"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SOURCES_ENV, tt.spec)
+
+			sources, err := SourcesFromEnv()
+			if err != nil {
+				t.Fatalf("SourcesFromEnv() error = %v", err)
+			}
+			var got []string
+			for _, s := range sources {
+				got = append(got, reflect.TypeOf(s).String())
+			}
+			if !reflect.DeepEqual(got, tt.types) {
+				t.Errorf("SourcesFromEnv() types = %v, want %v", got, tt.types)
+			}
+		})
+	}
+}
+
+func TestSourcesFromEnv_FileDefaults(t *testing.T) {
+	t.Setenv(SOURCES_ENV, "file")
+	t.Setenv("CONFIG_PATH", "/etc/orders")
+	t.Setenv("APP_PROFILE", "prod")
+
+	sources, err := SourcesFromEnv()
+	if err != nil {
+		t.Fatalf("SourcesFromEnv() error = %v", err)
+	}
+	file := sources[0].(*FileSource)
+	if file.BasePath != "/etc/orders" || file.Profile != "prod" {
+		t.Errorf("FileSource = {BasePath: %q, Profile: %q}, want /etc/orders and prod", file.BasePath, file.Profile)
+	}
+}
+
+func TestSourcesFromEnv_Override(t *testing.T) {
+	t.Setenv(SOURCES_ENV, "file,env,override")
+
+	sources, err := SourcesFromEnv()
+	if err != nil {
+		t.Fatalf("SourcesFromEnv() error = %v", err)
+	}
+	override, ok := sources[len(sources)-1].(*Override)
+	if !ok {
+		t.Fatalf("last source = %T, want *source.Override", sources[len(sources)-1])
+	}
+	override.Set("server.addr", ":9090")
+	result, err := override.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(result, map[string]any{"server": map[string]any{"addr": ":9090"}}) {
+		t.Errorf("Load() = %v, want the value Set on the returned Override", result)
+	}
+}
+
+func TestSourcesFromEnv_Unknown(t *testing.T) {
+	t.Setenv(SOURCES_ENV, "file,consul")
+
+	if _, err := SourcesFromEnv(); err == nil {
+		t.Error("SourcesFromEnv() with unknown source: expected error")
+	}
+}
+
+func TestEnvSource_Load_SkipsSourcesVar(t *testing.T) {
+	t.Setenv(SOURCES_ENV, "file,env")
+
+	result, err := (&EnvSource{}).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := result["sources"]; ok {
+		t.Errorf("Load() included %s: %v", SOURCES_ENV, result)
+	}
+}
@@ -0,0 +1,120 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+// SQLSource loads configuration from key/value rows in a database table.
+//
+// Query must return two columns, a dotted key and its value:
+//
+//	CREATE TABLE config (key text PRIMARY KEY, value text, updated_at timestamptz);
+//
+//	source := &source.SQLSource{
+//	    DB:           db, // *sql.DB opened with any driver
+//	    Query:        "SELECT key, value FROM config WHERE service = $1",
+//	    Args:         []any{"orders"},
+//	    VersionQuery: "SELECT max(updated_at) FROM config WHERE service = $1",
+//	    Interval:     30 * time.Second,
+//	}
+//
+// Keys are split on "." into nested maps, so a row ("server.addr", ":8080")
+// becomes {"server": {"addr": ":8080"}}. Values are strings (NULL becomes
+// ""), converted to field types by the Binder like env values.
+//
+// SQLSource only uses database/sql; the caller imports and registers the
+// driver.
+type SQLSource struct {
+	// DB is the database to query.
+	DB *sql.DB
+
+	// Query selects (key, value) rows.
+	Query string
+
+	// Args are passed to Query and VersionQuery.
+	Args []any
+
+	// VersionQuery returns a single value that changes whenever the rows
+	// do, typically the max of a last-updated column. Watch polls it.
+	VersionQuery string
+
+	// Interval between VersionQuery polls. Zero disables watching.
+	Interval time.Duration
+}
+
+// Name returns the identifier for this source.
+func (s *SQLSource) Name() string { return "sql" }
+
+// StringValued reports that all values are strings, so strict binding
+// coerces them to their field types.
+func (s *SQLSource) StringValued() bool { return true }
+
+// Load runs Query and builds the nested map from its rows. The context
+// bounds the query.
+func (s *SQLSource) Load(ctx context.Context) (map[string]any, error) {
+	rows, err := s.DB.QueryContext(ctx, s.Query, s.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("sql source: query: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]any)
+	for rows.Next() {
+		var key string
+		var value sql.NullString
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("sql source: scan: %w", err)
+		}
+		setNestedValue(result, strings.Split(key, "."), value.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sql source: rows: %w", err)
+	}
+	return result, nil
+}
+
+// Watch polls VersionQuery every Interval and sends an Event when its
+// result changes. Failed polls are skipped; the next tick tries again.
+//
+// Returns nil immediately if Interval or VersionQuery is unset, otherwise
+// blocks until the context is cancelled and returns ctx.Err().
+func (s *SQLSource) Watch(ctx context.Context, ch chan<- config.Event) error {
+	if s.Interval <= 0 || s.VersionQuery == "" {
+		return nil
+	}
+
+	last, _ := s.version(ctx)
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			current, err := s.version(ctx)
+			if err != nil || reflect.DeepEqual(current, last) {
+				continue
+			}
+			last = current
+			select {
+			case ch <- config.Event{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (s *SQLSource) version(ctx context.Context) (any, error) {
+	var v any
+	err := s.DB.QueryRowContext(ctx, s.VersionQuery, s.Args...).Scan(&v)
+	return v, err
+}
@@ -0,0 +1,181 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+// fakeTable is an in-memory config table served by the "fakesql" driver.
+// Queries equal to versionQuery return the version; any other query returns
+// the key/value rows.
+type fakeTable struct {
+	mu      sync.Mutex
+	rows    [][]driver.Value
+	version int64
+}
+
+const versionQuery = "SELECT max(updated_at) FROM config"
+
+var (
+	fakeTables   sync.Map // dsn -> *fakeTable
+	registerOnce sync.Once
+)
+
+func openFakeDB(t *testing.T, table *fakeTable) *sql.DB {
+	t.Helper()
+	registerOnce.Do(func() { sql.Register("fakesql", fakeDriver{}) })
+	fakeTables.Store(t.Name(), table)
+	db, err := sql.Open("fakesql", t.Name())
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	table, ok := fakeTables.Load(dsn)
+	if !ok {
+		return nil, errors.New("unknown table")
+	}
+	return &fakeConn{table: table.(*fakeTable)}, nil
+}
+
+type fakeConn struct{ table *fakeTable }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{table: c.table, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	table *fakeTable
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+	if s.query == versionQuery {
+		return &fakeRows{cols: []string{"max"}, rows: [][]driver.Value{{s.table.version}}}, nil
+	}
+	return &fakeRows{cols: []string{"key", "value"}, rows: append([][]driver.Value(nil), s.table.rows...)}, nil
+}
+
+type fakeRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestSQLSource_Name(t *testing.T) {
+	source := &SQLSource{}
+	expected := "sql"
+	if got := source.Name(); got != expected {
+		t.Errorf("Name() = %v, want %v", got, expected)
+	}
+}
+
+func TestSQLSource_Load(t *testing.T) {
+	db := openFakeDB(t, &fakeTable{rows: [][]driver.Value{
+		{"app.name", "orders"},
+		{"server.addr", ":8080"},
+		{"server.readTimeout", "5s"},
+		{"debug", nil},
+	}})
+
+	source := &SQLSource{DB: db, Query: "SELECT key, value FROM config"}
+	result, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"app":    map[string]any{"name": "orders"},
+		"server": map[string]any{"addr": ":8080", "readTimeout": "5s"},
+		"debug":  "",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Load() = %v, want %v", result, expected)
+	}
+}
+
+func TestSQLSource_Load_ContextCancelled(t *testing.T) {
+	db := openFakeDB(t, &fakeTable{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	source := &SQLSource{DB: db, Query: "SELECT key, value FROM config"}
+	if _, err := source.Load(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Load() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestSQLSource_Watch(t *testing.T) {
+	table := &fakeTable{version: 1}
+	db := openFakeDB(t, table)
+	source := &SQLSource{
+		DB:           db,
+		Query:        "SELECT key, value FROM config",
+		VersionQuery: versionQuery,
+		Interval:     10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan config.Event, 1)
+	done := make(chan error, 1)
+	go func() { done <- source.Watch(ctx, ch) }()
+
+	time.Sleep(30 * time.Millisecond)
+	table.mu.Lock()
+	table.version = 2
+	table.mu.Unlock()
+
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch did not report the version change")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Watch() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestSQLSource_Watch_Disabled(t *testing.T) {
+	source := &SQLSource{}
+	if err := source.Watch(context.Background(), make(chan config.Event)); err != nil {
+		t.Errorf("Watch() error = %v, want nil", err)
+	}
+}
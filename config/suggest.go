@@ -0,0 +1,111 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// unknownKeysError builds the error for the keys of source that mapstructure
+// left unused decoding into type t. Unused maps are listed by their leaves,
+// so each key can be matched to the closest known key path of t.
+func unknownKeysError(source map[string]any, unused []string, t reflect.Type) *UnknownKeysError {
+	var keys []string
+	for _, path := range unused {
+		v, _ := lookupPath(source, path)
+		keys = appendLeaves(keys, path, v)
+	}
+	slices.Sort(keys)
+
+	known := knownKeys(t)
+	err := &UnknownKeysError{Keys: keys}
+	for _, k := range keys {
+		if s, ok := closestKey(k, known); ok {
+			if err.Suggestions == nil {
+				err.Suggestions = make(map[string]string)
+			}
+			err.Suggestions[k] = s
+		}
+	}
+	return err
+}
+
+// appendLeaves appends path, or the paths of the leaves below it if v is a
+// non-empty map.
+func appendLeaves(out []string, path string, v any) []string {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) == 0 {
+		return append(out, path)
+	}
+	for k, nested := range m {
+		out = appendLeaves(out, joinPath(path, k), nested)
+	}
+	return out
+}
+
+// knownKeys returns the dotted paths of every field of struct type t and
+// of the structs nested in it, as source keys would address them.
+func knownKeys(t reflect.Type) []string {
+	var out []string
+	collectKeys(indirectType(t), "", map[reflect.Type]bool{}, &out)
+	return out
+}
+
+func collectKeys(t reflect.Type, prefix string, visiting map[reflect.Type]bool, out *[]string) {
+	if !IsNested(t) || visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			collectKeys(f.Type, prefix, visiting, out)
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		path := joinPath(prefix, key)
+		*out = append(*out, path)
+		collectKeys(indirectType(f.Type), path, visiting, out)
+	}
+}
+
+// closestKey returns the known key nearest to key by edit distance,
+// ignoring case, if it's close enough to be a likely typo: within a third
+// of key's length, and at least one edit.
+func closestKey(key string, known []string) (string, bool) {
+	limit := max(len(key)/3, 1)
+	best, bestDist := "", limit+1
+	lower := strings.ToLower(key)
+	for _, k := range known {
+		if d := levenshtein(lower, strings.ToLower(k)); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best, best != ""
+}
+
+// levenshtein returns the edit distance between a and b, in bytes.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// rewriteFunc returns the value to bind in place of the string s, set in
+// the source at path for field f, or false to leave s as it is.
+type rewriteFunc func(f reflect.StructField, path, s string) (any, bool, error)
+
+// rewriteTagged returns source with the string values of fields of struct
+// type t that have the given tag replaced by rewrite, for tags that change
+// how a value is decoded.
+//
+// Maps on the way to a rewritten value are copied, so source itself, which
+// the Manager keeps as the merged config, is left as it was. changed is
+// false, and out is source, if nothing was rewritten.
+func rewriteTagged(source map[string]any, t reflect.Type, prefix, tag string, rewrite rewriteFunc) (out map[string]any, changed bool, err error) {
+	out = source
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct || !hasTaggedFields(t, tag) {
+		return out, false, nil
+	}
+
+	for k, v := range source {
+		f, ok := lookupField(t, k)
+		if !ok {
+			continue
+		}
+		path := joinPath(prefix, k)
+
+		var rewritten any
+		switch v := v.(type) {
+		case string:
+			if _, ok := f.Tag.Lookup(tag); !ok {
+				continue
+			}
+			r, ok, err := rewrite(f, path, v)
+			if err != nil {
+				return nil, false, err
+			}
+			if !ok {
+				continue
+			}
+			rewritten = r
+		case map[string]any:
+			m, nestedChanged, err := rewriteTagged(v, f.Type, path, tag, rewrite)
+			if err != nil {
+				return nil, false, err
+			}
+			if !nestedChanged {
+				continue
+			}
+			rewritten = m
+		default:
+			continue
+		}
+
+		if !changed {
+			out = maps.Clone(source)
+			changed = true
+		}
+		out[k] = rewritten
+	}
+	return out, changed, nil
+}
+
+// taggedTypes caches hasTaggedFields by type and tag.
+var taggedTypes sync.Map
+
+type taggedKey struct {
+	t   reflect.Type
+	tag string
+}
+
+// hasTaggedFields reports whether struct type t or a struct nested in it
+// has a field with tag, so binds of the many configs without one skip
+// walking the source.
+func hasTaggedFields(t reflect.Type, tag string) bool {
+	key := taggedKey{t, tag}
+	if v, ok := taggedTypes.Load(key); ok {
+		return v.(bool)
+	}
+	has := findTaggedFields(t, tag, map[reflect.Type]bool{})
+	taggedTypes.Store(key, has)
+	return has
+}
+
+func findTaggedFields(t reflect.Type, tag string, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup(tag); ok {
+			return true
+		}
+		if ft := indirectType(f.Type); ft.Kind() == reflect.Struct && findTaggedFields(ft, tag, seen) {
+			return true
+		}
+	}
+	return false
+}
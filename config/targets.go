@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// TargetConflictError reports a config path that two structs bound from
+// the same sources would decode as different types.
+type TargetConflictError struct {
+	// Path is the dotted config path, as spelled in the first target.
+	Path string
+
+	// First and Second are the field types in the two targets.
+	First, Second reflect.Type
+}
+
+// Error implements the error interface.
+func (e *TargetConflictError) Error() string {
+	return fmt.Sprintf("config: %s is bound as %s and as %s", e.Path, e.First, e.Second)
+}
+
+// CompatibleTargets checks that config structs meant to be bound from the
+// same sources agree on the type of every path they share, e.g. when
+// several modules each bind their own struct with a Binder or Manager over
+// one set of sources. A path that's an int in one struct and a string in
+// another would otherwise decode differently for each without complaint,
+// or fail for only one of them. NewManager runs it over cfg and
+// Options.Targets.
+//
+// Each target may be a struct, a pointer to one, or a reflect.Type. Paths
+// are compared case-insensitively, as the Binder matches keys. Nested
+// structs are compared field by field; any other pair of types must be
+// identical (after dereferencing pointers). All conflicts are returned,
+// joined, as *TargetConflictError values.
+func CompatibleTargets(targets ...any) error {
+	seen := map[string]reflect.Type{}
+	spelled := map[string]string{}
+	var errs []error
+	for _, target := range targets {
+		t, ok := target.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(target)
+		}
+		fields := map[string]reflect.Type{}
+		paths := map[string]string{}
+		collectFieldTypes(t, "", map[reflect.Type]bool{}, fields, paths)
+
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ft := fields[k]
+			prev, ok := seen[k]
+			if !ok {
+				seen[k], spelled[k] = ft, paths[k]
+				continue
+			}
+			if prev == ft || (IsNested(prev) && IsNested(ft)) {
+				continue
+			}
+			errs = append(errs, &TargetConflictError{Path: spelled[k], First: prev, Second: ft})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// collectFieldTypes records the type of every bindable field path in t,
+// keyed by lowercased path, with the path as spelled in paths. Types
+// already being visited aren't entered again, so recursive types end.
+func collectFieldTypes(t reflect.Type, prefix string, visiting map[reflect.Type]bool, out map[string]reflect.Type, paths map[string]string) {
+	if t == nil {
+		return
+	}
+	t = indirectType(t)
+	if !IsNested(t) || visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if Squashed(f) {
+			collectFieldTypes(f.Type, prefix, visiting, out, paths)
+			continue
+		}
+		key, ok := FieldKey(f)
+		if !ok {
+			continue
+		}
+		path := joinPath(prefix, key)
+		lower := strings.ToLower(path)
+		out[lower], paths[lower] = indirectType(f.Type), path
+		collectFieldTypes(f.Type, path, visiting, out, paths)
+	}
+}
@@ -0,0 +1,123 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
+
+func TestCompatibleTargets(t *testing.T) {
+	type WebConfig struct {
+		Server struct {
+			Port    int           `config:"port"`
+			Timeout time.Duration `config:"timeout"`
+		} `config:"server"`
+		StartedAt time.Time `config:"startedAt"`
+	}
+	type MetricsConfig struct {
+		Server struct {
+			Port *int `config:"PORT"`
+		} `config:"server"`
+		StartedAt time.Time `config:"startedat"`
+	}
+	type ConflictingConfig struct {
+		Server struct {
+			Port    string `config:"port"`
+			Timeout struct {
+				Read time.Duration `config:"read"`
+			} `config:"timeout"`
+		} `config:"server"`
+	}
+
+	if err := config.CompatibleTargets(&WebConfig{}, MetricsConfig{}); err != nil {
+		t.Errorf("CompatibleTargets() error = %v, want nil", err)
+	}
+
+	err := config.CompatibleTargets(&WebConfig{}, reflect.TypeOf(ConflictingConfig{}))
+	if err == nil {
+		t.Fatal("CompatibleTargets() = nil, want conflicts")
+	}
+	var conflict *config.TargetConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("error = %v, want *TargetConflictError", err)
+	}
+	if conflict.Path != "server.port" || conflict.First != reflect.TypeOf(0) || conflict.Second != reflect.TypeOf("") {
+		t.Errorf("conflict = %+v, want server.port int vs string", conflict)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "server.port is bound as int and as string") ||
+		!strings.Contains(msg, "server.timeout is bound as time.Duration and as struct") {
+		t.Errorf("error = %q, want both conflicts", msg)
+	}
+}
+
+func TestManager_Targets(t *testing.T) {
+	type AppConfig struct {
+		Server struct {
+			Port int `config:"port"`
+		} `config:"server"`
+	}
+	type MetricsConfig struct {
+		Server struct {
+			Port int `config:"port"`
+		} `config:"server"`
+		Path string `config:"path"`
+	}
+	type ConflictingConfig struct {
+		Server struct {
+			Port string `config:"port"`
+		} `config:"server"`
+	}
+
+	src := &mockSource{
+		name: "file",
+		data: map[string]any{"server": map[string]any{"port": 8080}, "path": "/metrics"},
+	}
+
+	var cfg AppConfig
+	var metrics MetricsConfig
+	if _, err := config.NewManager(&cfg, config.Options{Targets: []any{&metrics}}, src); err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if metrics.Server.Port != 8080 || metrics.Path != "/metrics" {
+		t.Errorf("target = %+v, want it bound from the sources", metrics)
+	}
+
+	var conflicting ConflictingConfig
+	_, err := config.NewManager(&cfg, config.Options{Targets: []any{&conflicting}}, src)
+	var conflict *config.TargetConflictError
+	if !errors.As(err, &conflict) || conflict.Path != "server.port" {
+		t.Errorf("NewManager() error = %v, want a conflict on server.port", err)
+	}
+
+	if _, err := config.NewManager(&cfg, config.Options{Targets: []any{metrics}}, src); err == nil {
+		t.Error("NewManager() = nil error for a non-pointer target")
+	}
+}
+
+func TestCompatibleTargets_RecursiveType(t *testing.T) {
+	type Node struct {
+		Name string `config:"name"`
+		Next *Node  `config:"next"`
+	}
+	type Other struct {
+		Name int `config:"name"`
+	}
+
+	if err := config.CompatibleTargets(&Node{}, &Node{}); err != nil {
+		t.Errorf("CompatibleTargets() error = %v, want nil", err)
+	}
+	var conflict *config.TargetConflictError
+	if err := config.CompatibleTargets(&Node{}, &Other{}); !errors.As(err, &conflict) || conflict.Path != "name" {
+		t.Errorf("CompatibleTargets() error = %v, want a conflict on name", err)
+	}
+
+	src := &mockSource{name: "file", data: map[string]any{"name": "root"}}
+	var cfg, other Node
+	if _, err := config.NewManager(&cfg, config.Options{Targets: []any{&other}}, src); err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+}
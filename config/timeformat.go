@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// timeLayouts are the layout names a timeformat tag may use instead of a
+// layout, e.g. `timeformat:"DateOnly"`.
+var timeLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+	"Kitchen":     time.Kitchen,
+}
+
+// parseTimeFormats returns source with the strings bound to time.Time
+// fields tagged `timeformat:"layout"` parsed with their layout (a
+// time.Parse layout such as "2006-01-02 15:04", or the name of one of the
+// time package's, such as "DateOnly"). Other time.Time fields are parsed
+// as RFC 3339 by the decode hooks.
+func parseTimeFormats(source map[string]any, t reflect.Type) (map[string]any, error) {
+	out, _, err := rewriteTagged(source, t, "", "timeformat", func(f reflect.StructField, path, s string) (any, bool, error) {
+		if indirectType(f.Type) != timeType {
+			return nil, false, nil
+		}
+		layout := f.Tag.Get("timeformat")
+		if named, ok := timeLayouts[layout]; ok {
+			layout = named
+		}
+		tm, err := time.Parse(layout, s)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: invalid time: %w", path, err)
+		}
+		return tm, true, nil
+	})
+	return out, err
+}
+
+// stringToTimeHookFunc parses strings bound to time.Time fields as RFC 3339
+// ("2024-01-01T00:00:00Z", fractional seconds allowed).
+func stringToTimeHookFunc() func(from, to reflect.Type, data any) (any, error) {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != timeType {
+			return data, nil
+		}
+		return time.Parse(time.RFC3339, data.(string))
+	}
+}
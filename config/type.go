@@ -46,6 +46,17 @@ type ConfigSource interface {
 	Name() string
 }
 
+// StringValued is implemented by sources whose values are always strings,
+// such as environment variables and command-line flags.
+//
+// With Options.StrictTypes, the Manager coerces the values of such sources
+// to the types of the fields they bind to before merging, since strict
+// binding doesn't convert "8080" to an int on its own. Sources that produce
+// typed values (YAML, JSON) don't implement it and are bound strictly.
+type StringValued interface {
+	StringValued() bool
+}
+
 // Event represents a configuration change notification.
 //
 // Events are sent to subscribers when the configuration is reloaded and
@@ -65,4 +76,16 @@ type Event struct {
 	// NewConfig is the configuration value after the change.
 	// The actual type depends on the configuration struct passed to Manager.
 	NewConfig any
+
+	// ack, set with Options.SyncNotify, tells Reload this subscriber is done.
+	ack func()
+}
+
+// Ack reports that the subscriber has finished applying the change. With
+// Options.SyncNotify, Reload waits for every subscriber to Ack its Event;
+// otherwise Ack does nothing. Calling it more than once is harmless.
+func (e Event) Ack() {
+	if e.ack != nil {
+		e.ack()
+	}
 }
@@ -3,46 +3,179 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"slices"
 	"sort"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
 
+// ErrAlreadyRun is returned by Run when the App has already been run.
+var ErrAlreadyRun = errors.New("core: app already running or ran")
+
+// DefaultShutdownTimeout bounds the shutdown of an App whose
+// ShutdownTimeout is zero.
+const DefaultShutdownTimeout = 15 * time.Second
+
 type App struct {
 	Modules   []Module
 	Container Container
 	Logger    *slog.Logger
+
+	// ShutdownTimeout bounds stopping the modules and waiting for their
+	// background goroutines; zero means DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	ran atomic.Bool
 }
 
+// Option configures an App built with New.
+type Option func(*App)
+
+// WithModules adds modules to the App.
+func WithModules(mods ...Module) Option {
+	return func(a *App) { a.Modules = append(a.Modules, mods...) }
+}
+
+// WithContainer makes the App use c, which may already hold shared objects,
+// instead of a fresh container.
+func WithContainer(c Container) Option {
+	return func(a *App) { a.Container = c }
+}
+
+// WithValue seeds the App's container with val under key, so it's available
+// to modules from Configure onwards. Options apply in order, so WithValue
+// after WithContainer seeds the provided container.
+//
+// Example:
+//
+//	app := core.New(logger,
+//	    core.WithValue(core.TypeKey[config.Root]{}, cfg),
+//	    core.WithModules(web.Module()),
+//	)
+func WithValue(key, val any) Option {
+	return func(a *App) { a.Container.Set(key, val) }
+}
+
+// WithShutdownTimeout sets the App's ShutdownTimeout.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(a *App) { a.ShutdownTimeout = d }
+}
+
+// NewApp creates an App running mods with a fresh container.
 func NewApp(logger *slog.Logger, mods ...Module) *App {
-	return &App{
-		Modules:   mods,
+	return New(logger, WithModules(mods...))
+}
+
+// New creates an App configured by opts. Without WithContainer, the App gets
+// a fresh container.
+func New(logger *slog.Logger, opts ...Option) *App {
+	a := &App{
 		Container: NewContainer(),
 		Logger:    logger,
 	}
+	for _, o := range opts {
+		o(a)
+	}
+	return a
 }
 
-func (a *App) Run(ctx context.Context) error {
+// Run configures and starts the modules, waits for a shutdown signal, then
+// stops them. After the modules stop, resources registered with OnShutdown
+// are flushed and closed so buffered data is written before exit; this also
+// happens when a module fails to start.
+//
+// An App can only be run once; later calls return ErrAlreadyRun.
+func (a *App) Run(ctx context.Context) (runErr error) {
+	if !a.ran.CompareAndSwap(false, true) {
+		return ErrAlreadyRun
+	}
+
 	// 1) Order modules by dependencies (simple topo-sort)
 	order, err := topoSort(a.Modules)
 	if err != nil {
 		return err
 	}
 
-	// 2) Configure
+	// Background goroutines registered by modules; a failure triggers shutdown.
+	group := NewGroup(context.Background())
+	Put(a.Container, group)
+	// On early returns too, cancel the goroutines' context and wait for
+	// them, so a failed Configure or Start doesn't leak them.
+	waited := false
+	waitGroup := func() {
+		if waited {
+			return
+		}
+		waited = true
+		waitCtx, cancel := a.shutdownContext()
+		defer cancel()
+		if err := group.WaitContext(waitCtx); err != nil {
+			a.Logger.Error("background goroutines did not stop", "error", err)
+		}
+	}
+	defer waitGroup()
+
+	// 2) Configure, verifying each module's declared requirements first so
+	//    a missing one is an error rather than a panic in Configure. Modules
+	//    with missing requirements, and those depending on them, aren't
+	//    configured. After a Configure error the remaining modules' are
+	//    still checked, so all missing entries are reported together.
+	var (
+		missing   []error
+		configErr error
+	)
+	failed := map[string]bool{}
 	for _, m := range order {
+		if slices.ContainsFunc(m.DependsOn(), func(d string) bool { return failed[d] }) {
+			failed[m.Name()] = true
+			continue
+		}
+		if errs := missingRequirements(m, a.Container); len(errs) > 0 {
+			missing = append(missing, errs...)
+			failed[m.Name()] = true
+			continue
+		}
+		if configErr != nil {
+			continue
+		}
 		if err := m.Configure(a.Container); err != nil {
-			return err
+			configErr = err
+			failed[m.Name()] = true
 		}
 	}
+	if len(missing) > 0 {
+		return errors.Join(fmt.Errorf("missing dependencies: %w", errors.Join(missing...)), configErr)
+	}
+	if configErr != nil {
+		return configErr
+	}
 
-	// 3) Start in order
-	for _, m := range order {
+	// Flush buffered logs/telemetry and close resources registered with
+	// OnShutdown however Run returns from here, once the background
+	// goroutines are done writing to them. A flush error is returned only
+	// if nothing failed before it.
+	defer func() {
+		waitGroup()
+		if err := flushAll(a.Container); err != nil && runErr == nil {
+			runErr = err
+		}
+	}()
+
+	// 3) Start in order; if one fails, stop those already started
+	for i, m := range order {
 		a.Logger.Info("starting module", "module", m.Name())
 		if err := m.Start(ctx, a.Container); err != nil {
+			shutdownCtx, cancel := a.shutdownContext()
+			defer cancel()
+			if stopErr := a.stopAll(shutdownCtx, order[:i]); stopErr != nil {
+				a.Logger.Error("stopping started modules failed", "error", stopErr)
+			}
 			return err
 		}
 	}
@@ -53,23 +186,78 @@ func (a *App) Run(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 	case <-stop:
+	case <-group.Failed():
+		a.Logger.Error("background task failed, shutting down", "error", group.Err())
 	}
 
 	// give modules time to shutdown
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	shutdownCtx, cancel := a.shutdownContext()
 	defer cancel()
 
 	var firstErr error
+
+	// 5) Pre-stop pass: let modules stop accepting new work while every
+	//    dependency is still running.
 	for i := len(order) - 1; i >= 0; i-- {
 		m := order[i]
+		if err := preStop(shutdownCtx, m, a.Container); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// 6) Stop in reverse order
+	if err := a.stopAll(shutdownCtx, order); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	// 7) Wait for background goroutines, within what's left of the
+	//    shutdown timeout; their failure is the root cause.
+	groupErr := group.WaitContext(shutdownCtx)
+	waited = true
+	if groupErr != nil {
+		return groupErr
+	}
+	return firstErr
+}
+
+// shutdownContext returns a context bounded by the App's shutdown timeout.
+func (a *App) shutdownContext() (context.Context, context.CancelFunc) {
+	timeout := a.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// stopAll stops mods in reverse order, returning the first error.
+func (a *App) stopAll(ctx context.Context, mods []Module) error {
+	var firstErr error
+	for i := len(mods) - 1; i >= 0; i-- {
+		m := mods[i]
 		a.Logger.Info("stopping module", "module", m.Name())
-		if err := m.Stop(shutdownCtx, a.Container); err != nil && firstErr == nil {
+		if err := m.Stop(ctx, a.Container); err != nil && firstErr == nil {
 			firstErr = err
 		}
 	}
 	return firstErr
 }
 
+// missingRequirements returns an error for each container entry m
+// declares as a Requirer that isn't present.
+func missingRequirements(m Module, c Container) []error {
+	r, ok := m.(Requirer)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for _, key := range r.Requires() {
+		if _, ok := c.Get(key); !ok {
+			errs = append(errs, fmt.Errorf("module %s requires %v", m.Name(), key))
+		}
+	}
+	return errs
+}
+
 func topoSort(mods []Module) ([]Module, error) {
 	nameToMod := map[string]Module{}
 	for _, m := range mods {
@@ -80,17 +268,21 @@ func topoSort(mods []Module) ([]Module, error) {
 	}
 	visited := map[string]bool{}
 	temp := map[string]bool{}
+	var stack []string // modules on the current visit path
 	var out []Module
 	var visit func(string) error
 
 	visit = func(n string) error {
 		if temp[n] {
-			return errors.New("cycle detected at module " + n)
+			// n is on the stack: the cycle is the path from n back to n.
+			cycle := append(stack[slices.Index(stack, n):], n)
+			return errors.New("cycle detected: " + strings.Join(cycle, " -> "))
 		}
 		if visited[n] {
 			return nil
 		}
 		temp[n] = true
+		stack = append(stack, n)
 		m := nameToMod[n]
 		for _, d := range m.DependsOn() {
 			if _, ok := nameToMod[d]; !ok {
@@ -100,8 +292,17 @@ func topoSort(mods []Module) ([]Module, error) {
 				return err
 			}
 		}
+		for _, d := range optionalDeps(m) {
+			if _, ok := nameToMod[d]; !ok {
+				continue
+			}
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
 		visited[n] = true
 		temp[n] = false
+		stack = stack[:len(stack)-1]
 		out = append(out, m)
 		return nil
 	}
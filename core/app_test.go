@@ -0,0 +1,608 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects lifecycle calls across modules in the order they happen.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) add(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// testModule is a configurable Module used to observe App lifecycle ordering.
+type testModule struct {
+	name    string
+	deps    []string
+	rec     *recorder
+	started chan struct{}
+}
+
+func (m *testModule) Name() string        { return m.name }
+func (m *testModule) DependsOn() []string { return m.deps }
+
+func (m *testModule) Configure(c Container) error { return nil }
+
+func (m *testModule) Start(ctx context.Context, c Container) error {
+	m.rec.add("start:" + m.name)
+	if m.started != nil {
+		close(m.started)
+	}
+	return nil
+}
+
+func (m *testModule) Stop(ctx context.Context, c Container) error {
+	m.rec.add("stop:" + m.name)
+	return nil
+}
+
+// preStopModule additionally implements PreStopper.
+type preStopModule struct {
+	testModule
+}
+
+func (m *preStopModule) PreStop(ctx context.Context, c Container) error {
+	m.rec.add("prestop:" + m.name)
+	return nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// runUntilStarted runs app until the module owning started has started, then
+// cancels the context and returns Run's result.
+func runUntilStarted(t *testing.T, app *App, started chan struct{}) error {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- app.Run(ctx) }()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("app did not start in time")
+	}
+	cancel()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("app did not stop in time")
+		return nil
+	}
+}
+
+func TestApp_Run_PreStopBeforeStop(t *testing.T) {
+	rec := &recorder{}
+	started := make(chan struct{})
+
+	web := &preStopModule{testModule{name: "web", deps: []string{"db"}, rec: rec}}
+	db := &testModule{name: "db", rec: rec}
+	api := &testModule{name: "api", deps: []string{"web"}, rec: rec, started: started}
+
+	app := NewApp(testLogger(), api, web, db)
+	if err := runUntilStarted(t, app, started); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"start:db", "start:web", "start:api",
+		"prestop:web",
+		"stop:api", "stop:web", "stop:db",
+	}
+	if got := rec.list(); !reflect.DeepEqual(got, want) {
+		t.Errorf("lifecycle calls = %v, want %v", got, want)
+	}
+}
+
+// failingModule registers a background goroutine that fails after start.
+type failingModule struct {
+	testModule
+	err error
+}
+
+func (m *failingModule) Start(ctx context.Context, c Container) error {
+	m.rec.add("start:" + m.name)
+	Get[*Group](c).Go(func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return m.err
+	})
+	return nil
+}
+
+func TestApp_Run_BackgroundFailure(t *testing.T) {
+	rec := &recorder{}
+	wantErr := errors.New("worker crashed")
+
+	worker := &failingModule{testModule: testModule{name: "worker", rec: rec}, err: wantErr}
+	other := &testModule{name: "other", rec: rec}
+
+	app := NewApp(testLogger(), worker, other)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- app.Run(context.Background()) }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, wantErr) {
+			t.Errorf("Run() error = %v, want %v", err, wantErr)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after background failure")
+	}
+
+	calls := rec.list()
+	for _, want := range []string{"stop:other", "stop:worker"} {
+		if !slices.Contains(calls, want) {
+			t.Errorf("lifecycle calls = %v, missing %q", calls, want)
+		}
+	}
+}
+
+// startErrModule fails to start.
+type startErrModule struct {
+	testModule
+	err error
+}
+
+func (m *startErrModule) Start(ctx context.Context, c Container) error {
+	m.rec.add("start:" + m.name)
+	return m.err
+}
+
+// goroutineModule runs a background goroutine until the Group cancels it.
+type goroutineModule struct {
+	testModule
+	exited chan struct{}
+}
+
+func (m *goroutineModule) Start(ctx context.Context, c Container) error {
+	m.rec.add("start:" + m.name)
+	Get[*Group](c).Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(m.exited)
+		return nil
+	})
+	return nil
+}
+
+func TestApp_Run_StartFailureStopsStartedModules(t *testing.T) {
+	rec := &recorder{}
+	wantErr := errors.New("listen failed")
+
+	db := &goroutineModule{testModule: testModule{name: "db", rec: rec}, exited: make(chan struct{})}
+	web := &startErrModule{testModule: testModule{name: "web", deps: []string{"db"}, rec: rec}, err: wantErr}
+	api := &testModule{name: "api", deps: []string{"web"}, rec: rec}
+
+	err := NewApp(testLogger(), api, web, db).Run(context.Background())
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Run() error = %v, want %v", err, wantErr)
+	}
+
+	want := []string{"start:db", "start:web", "stop:db"}
+	if got := rec.list(); !reflect.DeepEqual(got, want) {
+		t.Errorf("lifecycle calls = %v, want %v", got, want)
+	}
+	select {
+	case <-db.exited:
+	default:
+		t.Error("background goroutine still running after Run returned")
+	}
+}
+
+func TestApp_Run_StartFailureFlushes(t *testing.T) {
+	rec := &recorder{}
+	wantErr := errors.New("listen failed")
+	app := NewApp(testLogger(), &startErrModule{testModule: testModule{name: "web", rec: rec}, err: wantErr})
+	OnShutdown(app.Container, &flushable{name: "logs", rec: rec, err: errors.New("exporter unreachable")})
+
+	if err := app.Run(context.Background()); !errors.Is(err, wantErr) {
+		t.Fatalf("Run() error = %v, want the Start error %v", err, wantErr)
+	}
+	want := []string{"start:web", "flush:logs", "close:logs"}
+	if got := rec.list(); !reflect.DeepEqual(got, want) {
+		t.Errorf("lifecycle calls = %v, want %v", got, want)
+	}
+}
+
+// stuckModule starts a goroutine that ignores cancellation.
+type stuckModule struct {
+	testModule
+	release chan struct{}
+}
+
+func (m *stuckModule) Start(ctx context.Context, c Container) error {
+	m.rec.add("start:" + m.name)
+	Get[*Group](c).Go(func(context.Context) error {
+		<-m.release
+		return nil
+	})
+	return nil
+}
+
+func TestApp_Run_ShutdownTimeoutBoundsGoroutines(t *testing.T) {
+	rec := &recorder{}
+	stuck := &stuckModule{testModule: testModule{name: "stuck", rec: rec}, release: make(chan struct{})}
+	defer close(stuck.release)
+	flushed := &flushable{name: "logs", rec: rec}
+
+	app := New(testLogger(), WithModules(stuck), WithShutdownTimeout(50*time.Millisecond))
+	OnShutdown(app.Container, flushed)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- app.Run(ctx) }()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Run() error = %v, want a shutdown timeout", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() waited on a goroutine that ignores cancellation")
+	}
+	if !slices.Contains(rec.list(), "close:logs") {
+		t.Errorf("lifecycle calls = %v, want resources closed after the timeout", rec.list())
+	}
+}
+
+func TestGroup_WaitCancelsContext(t *testing.T) {
+	g := NewGroup(context.Background())
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- g.Wait() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Wait() error = %v, want nil", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Wait() did not cancel the goroutine context")
+	}
+}
+
+func TestTopoSort_CyclePath(t *testing.T) {
+	rec := &recorder{}
+	mods := []Module{
+		&testModule{name: "a", deps: []string{"b"}, rec: rec},
+		&testModule{name: "b", deps: []string{"c"}, rec: rec},
+		&testModule{name: "c", deps: []string{"a"}, rec: rec},
+		&testModule{name: "d", rec: rec},
+	}
+
+	_, err := topoSort(mods)
+	if err == nil {
+		t.Fatal("topoSort() expected cycle error")
+	}
+	if want := "a -> b -> c -> a"; !strings.Contains(err.Error(), want) {
+		t.Errorf("topoSort() error = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestTopoSort_CycleNotAtRoot(t *testing.T) {
+	rec := &recorder{}
+	mods := []Module{
+		&testModule{name: "app", deps: []string{"db"}, rec: rec},
+		&testModule{name: "db", deps: []string{"metrics"}, rec: rec},
+		&testModule{name: "metrics", deps: []string{"db"}, rec: rec},
+	}
+
+	_, err := topoSort(mods)
+	if err == nil {
+		t.Fatal("topoSort() expected cycle error")
+	}
+	if want := "cycle detected: db -> metrics -> db"; err.Error() != want {
+		t.Errorf("topoSort() error = %q, want %q", err, want)
+	}
+}
+
+// softModule declares optional dependencies.
+type softModule struct {
+	testModule
+	optional []string
+}
+
+func (m *softModule) OptionalDependsOn() []string { return m.optional }
+
+func moduleNames(mods []Module) []string {
+	names := make([]string, len(mods))
+	for i, m := range mods {
+		names[i] = m.Name()
+	}
+	return names
+}
+
+func TestTopoSort_OptionalDependsOn(t *testing.T) {
+	rec := &recorder{}
+
+	t.Run("present dependency affects order", func(t *testing.T) {
+		// "api" sorts before "tracing", so only the soft dep puts tracing first.
+		mods := []Module{
+			&softModule{testModule{name: "api", rec: rec}, []string{"tracing"}},
+			&testModule{name: "tracing", rec: rec},
+		}
+		order, err := topoSort(mods)
+		if err != nil {
+			t.Fatalf("topoSort() error = %v", err)
+		}
+		if got, want := moduleNames(order), []string{"tracing", "api"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("order = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("absent dependency is ignored", func(t *testing.T) {
+		mods := []Module{
+			&softModule{testModule{name: "api", rec: rec}, []string{"tracing"}},
+			&testModule{name: "db", rec: rec},
+		}
+		order, err := topoSort(mods)
+		if err != nil {
+			t.Fatalf("topoSort() error = %v, want nil for absent optional dependency", err)
+		}
+		if got, want := moduleNames(order), []string{"api", "db"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("order = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("hard dependency stays strict", func(t *testing.T) {
+		mods := []Module{
+			&softModule{testModule{name: "api", deps: []string{"db"}, rec: rec}, []string{"tracing"}},
+		}
+		if _, err := topoSort(mods); err == nil {
+			t.Error("topoSort() expected missing dependency error")
+		}
+	})
+}
+
+func TestApp_Run_Twice(t *testing.T) {
+	rec := &recorder{}
+	started := make(chan struct{})
+	app := NewApp(testLogger(), &testModule{name: "only", rec: rec, started: started})
+
+	if err := runUntilStarted(t, app, started); err != nil {
+		t.Fatalf("first Run() error = %v", err)
+	}
+
+	if err := app.Run(context.Background()); !errors.Is(err, ErrAlreadyRun) {
+		t.Errorf("second Run() error = %v, want %v", err, ErrAlreadyRun)
+	}
+
+	starts := 0
+	for _, call := range rec.list() {
+		if call == "start:only" {
+			starts++
+		}
+	}
+	if starts != 1 {
+		t.Errorf("module started %d times, want 1", starts)
+	}
+}
+
+type configKey struct{}
+
+// seedCheckModule records what it finds in the container at Configure.
+type seedCheckModule struct {
+	testModule
+	seen map[string]any
+}
+
+func (m *seedCheckModule) Configure(c Container) error {
+	if v, ok := c.Get(configKey{}); ok {
+		m.seen["config"] = v
+	}
+	if v, ok := c.Get(TypeKey[*slog.Logger]{}); ok {
+		m.seen["logger"] = v
+	}
+	if v, ok := c.Get("shared"); ok {
+		m.seen["shared"] = v
+	}
+	return nil
+}
+
+func TestNew_SeededContainer(t *testing.T) {
+	rec := &recorder{}
+	started := make(chan struct{})
+	logger := testLogger()
+
+	shared := NewContainer()
+	shared.Set("shared", 42)
+
+	mod := &seedCheckModule{
+		testModule: testModule{name: "check", rec: rec, started: started},
+		seen:       map[string]any{},
+	}
+	app := New(logger,
+		WithContainer(shared),
+		WithValue(configKey{}, "cfg"),
+		WithValue(TypeKey[*slog.Logger]{}, logger),
+		WithModules(mod),
+	)
+
+	if app.Container != shared {
+		t.Error("New() did not use the provided container")
+	}
+	if err := runUntilStarted(t, app, started); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := map[string]any{"config": "cfg", "logger": logger, "shared": 42}
+	if !reflect.DeepEqual(mod.seen, want) {
+		t.Errorf("values seen at Configure = %v, want %v", mod.seen, want)
+	}
+}
+
+type database struct{}
+type cache struct{}
+
+// requiringModule declares container entries it needs.
+type requiringModule struct {
+	testModule
+	requires []any
+}
+
+func (m *requiringModule) Requires() []any { return m.requires }
+
+func TestApp_Run_MissingRequirements(t *testing.T) {
+	rec := &recorder{}
+	api := &requiringModule{
+		testModule: testModule{name: "api", rec: rec},
+		requires:   []any{TypeKey[*database]{}, TypeKey[*slog.Logger]{}},
+	}
+	worker := &requiringModule{
+		testModule: testModule{name: "worker", rec: rec},
+		requires:   []any{TypeKey[*cache]{}},
+	}
+
+	app := New(testLogger(),
+		WithValue(TypeKey[*slog.Logger]{}, testLogger()),
+		WithModules(api, worker),
+	)
+	err := app.Run(context.Background())
+	if err == nil {
+		t.Fatal("Run() expected missing dependency error")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{"module api requires *core.database", "module worker requires *core.cache"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Run() error = %q, want it to contain %q", msg, want)
+		}
+	}
+	if strings.Contains(msg, "slog") {
+		t.Errorf("Run() error = %q, should not report the registered logger", msg)
+	}
+	if calls := rec.list(); len(calls) != 0 {
+		t.Errorf("no module should start when requirements are missing, got %v", calls)
+	}
+}
+
+// configErrModule fails to configure.
+type configErrModule struct {
+	testModule
+	err error
+}
+
+func (m *configErrModule) Configure(c Container) error { return m.err }
+
+func TestApp_Run_ConfigureErrorKeepsMissingRequirements(t *testing.T) {
+	rec := &recorder{}
+	wantErr := errors.New("bad config")
+	broken := &configErrModule{testModule: testModule{name: "a-broken", rec: rec}, err: wantErr}
+	worker := &requiringModule{
+		testModule: testModule{name: "worker", rec: rec},
+		requires:   []any{TypeKey[*cache]{}},
+	}
+
+	err := NewApp(testLogger(), broken, worker).Run(context.Background())
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Run() error = %v, want it to wrap %v", err, wantErr)
+	}
+	if err == nil || !strings.Contains(err.Error(), "module worker requires *core.cache") {
+		t.Errorf("Run() error = %v, want the missing requirement reported too", err)
+	}
+}
+
+// flushable is a buffered resource recording Flush and Close calls.
+type flushable struct {
+	name string
+	rec  *recorder
+	err  error
+}
+
+func (f *flushable) Flush() error {
+	f.rec.add("flush:" + f.name)
+	return f.err
+}
+
+func (f *flushable) Close() error {
+	f.rec.add("close:" + f.name)
+	return nil
+}
+
+type (
+	logExporter   struct{ *flushable }
+	traceExporter struct{ *flushable }
+	lazyExporter  struct{ *flushable }
+)
+
+func TestApp_Run_FlushesAfterStop(t *testing.T) {
+	rec := &recorder{}
+	started := make(chan struct{})
+	app := NewApp(testLogger(), &testModule{name: "web", rec: rec, started: started})
+	logs := logExporter{&flushable{name: "logs", rec: rec}}
+	Put(app.Container, logs)
+	OnShutdown(app.Container, logs)
+	Provide(app.Container, func(c Container) (traceExporter, error) {
+		exp := traceExporter{&flushable{name: "traces", rec: rec}}
+		OnShutdown(c, exp)
+		return exp, nil
+	})
+	Provide(app.Container, func(c Container) (lazyExporter, error) {
+		rec.add("build:lazy")
+		exp := lazyExporter{&flushable{name: "lazy", rec: rec}}
+		OnShutdown(c, exp)
+		return exp, nil
+	})
+	Get[traceExporter](app.Container)
+	// Stored but not registered: owned by whoever put it there.
+	Put(app.Container, &flushable{name: "unowned", rec: rec})
+
+	if err := runUntilStarted(t, app, started); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	calls := rec.list()
+	stopAt := slices.Index(calls, "stop:web")
+	if stopAt < 0 {
+		t.Fatalf("calls = %v, web never stopped", calls)
+	}
+	want := []string{"flush:traces", "close:traces", "flush:logs", "close:logs"}
+	if got := calls[stopAt+1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls after stop = %v, want %v (reverse registration order)", got, want)
+	}
+	if slices.Contains(calls, "build:lazy") {
+		t.Errorf("calls = %v, shutdown built an unused provider", calls)
+	}
+}
+
+func TestApp_Run_ReturnsFlushError(t *testing.T) {
+	rec := &recorder{}
+	started := make(chan struct{})
+	app := NewApp(testLogger(), &testModule{name: "web", rec: rec, started: started})
+	wantErr := errors.New("exporter unreachable")
+	OnShutdown(app.Container, logExporter{&flushable{name: "logs", rec: rec, err: wantErr}})
+
+	if err := runUntilStarted(t, app, started); !errors.Is(err, wantErr) {
+		t.Errorf("Run() error = %v, want %v", err, wantErr)
+	}
+}
@@ -14,13 +14,29 @@ type Container interface {
 	MustGet(key any) any
 }
 
+// GetOrSetter is implemented by containers that can set a missing value
+// atomically, as NewContainer's does. GetOrSet returns the value for key,
+// first setting it to fn() if absent; under concurrent calls fn runs at
+// most once. fn may use the container, for keys other than key.
+type GetOrSetter interface {
+	GetOrSet(key any, fn func() any) any
+}
+
 type container struct {
-	mu  sync.RWMutex
-	reg map[any]any
+	mu      sync.RWMutex
+	reg     map[any]any
+	pending map[any]*pendingValue // keys whose GetOrSet fn is running
+}
+
+// pendingValue is a value being built by GetOrSet, which callers for the
+// same key wait on.
+type pendingValue struct {
+	once sync.Once
+	val  any
 }
 
 func NewContainer() Container {
-	return &container{reg: make(map[any]any)}
+	return &container{reg: make(map[any]any), pending: make(map[any]*pendingValue)}
 }
 
 func (c *container) Set(key, val any) {
@@ -43,13 +59,101 @@ func (c *container) MustGet(key any) any {
 	panic(fmt.Errorf("container: missing dependency %v (%T)", key, key))
 }
 
+func (c *container) GetOrSet(key any, fn func() any) any {
+	c.mu.Lock()
+	if v, ok := c.reg[key]; ok {
+		c.mu.Unlock()
+		return v
+	}
+	p, ok := c.pending[key]
+	if !ok {
+		p = &pendingValue{}
+		c.pending[key] = p
+	}
+	c.mu.Unlock()
+
+	// fn runs outside c.mu, so it can use the container.
+	p.once.Do(func() {
+		v := fn()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if existing, ok := c.reg[key]; ok {
+			v = existing // Set while fn ran
+		} else {
+			c.reg[key] = v
+		}
+		delete(c.pending, key)
+		p.val = v
+	})
+	return p.val
+}
+
 // Helpers for typed keys
 type TypeKey[T any] struct{}
 
+// String returns the name of T, for readable errors about missing keys.
+func (TypeKey[T]) String() string { return reflect.TypeFor[T]().String() }
+
 func Put[T any](c Container, v T) { c.Set(TypeKey[T]{}, v) }
 
+// GetOrPut returns the registered T, or constructs one with fn and Puts it
+// if there's none. It suits shared resources that whichever module needs
+// first should create. fn may use the container, except to get a T; use
+// Provide for values built lazily from other dependencies.
+//
+// On containers implementing GetOrSetter, such as NewContainer's, it's
+// atomic: under concurrent calls fn runs at most once and every caller
+// gets the same value. Other containers get a plain Get, then Put.
+func GetOrPut[T any](c Container, fn func() T) T {
+	if gs, ok := c.(GetOrSetter); ok {
+		gs.GetOrSet(TypeKey[T]{}, func() any { return fn() })
+		return Get[T](c)
+	}
+	if _, ok := c.Get(TypeKey[T]{}); !ok {
+		Put(c, fn())
+	}
+	return Get[T](c)
+}
+
+// Provide registers a factory for T that runs on the first Get[T], instead
+// of eagerly Putting a value. The result (or error) is memoized, so the
+// factory runs at most once even under concurrent Gets. The factory may Get
+// other dependencies from the container.
+//
+// Useful for expensive dependencies only some modules need:
+//
+//	core.Provide(c, func(c core.Container) (*sql.DB, error) {
+//	    return sql.Open("postgres", core.Get[config.Root](c).DB.URL)
+//	})
+//
+// Get panics if the factory returns an error, as it does for a missing
+// dependency.
+func Provide[T any](c Container, fn func(Container) (T, error)) {
+	c.Set(TypeKey[T]{}, &provider[T]{fn: fn})
+}
+
+// provider memoizes a factory registered with Provide.
+type provider[T any] struct {
+	once sync.Once
+	fn   func(Container) (T, error)
+	val  T
+	err  error
+}
+
+func (p *provider[T]) get(c Container) (T, error) {
+	p.once.Do(func() { p.val, p.err = p.fn(c) })
+	return p.val, p.err
+}
+
 func Get[T any](c Container) T {
 	raw := c.MustGet(TypeKey[T]{})
+	if p, ok := raw.(*provider[T]); ok {
+		v, err := p.get(c)
+		if err != nil {
+			panic(fmt.Errorf("container: provide %v: %w", TypeKey[T]{}, err))
+		}
+		return v
+	}
 	v, ok := raw.(T)
 	if !ok {
 		panic(fmt.Errorf("container: wrong type. have=%T want=%v", raw, reflect.TypeFor[T]()))
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type pool struct{ dsn string }
+
+func TestProvide_RunsOnce(t *testing.T) {
+	c := NewContainer()
+	var calls atomic.Int32
+	Provide(c, func(Container) (*pool, error) {
+		calls.Add(1)
+		return &pool{dsn: "postgres://"}, nil
+	})
+
+	if calls.Load() != 0 {
+		t.Fatal("factory ran before first Get")
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*pool, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = Get[*pool](c)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("factory ran %d times, want 1", got)
+	}
+	for _, p := range results {
+		if p != results[0] {
+			t.Fatal("Get returned different instances")
+		}
+	}
+}
+
+func TestProvide_PullsOtherDependencies(t *testing.T) {
+	c := NewContainer()
+	Put(c, "postgres://db.local")
+	Provide(c, func(c Container) (*pool, error) {
+		return &pool{dsn: Get[string](c)}, nil
+	})
+
+	if got := Get[*pool](c).dsn; got != "postgres://db.local" {
+		t.Errorf("dsn = %q, want %q", got, "postgres://db.local")
+	}
+}
+
+func TestProvide_ErrorPanics(t *testing.T) {
+	c := NewContainer()
+	wantErr := errors.New("connection refused")
+	Provide(c, func(Container) (*pool, error) { return nil, wantErr })
+
+	defer func() {
+		rec := recover()
+		err, ok := rec.(error)
+		if !ok || !errors.Is(err, wantErr) {
+			t.Errorf("panic = %v, want error wrapping %v", rec, wantErr)
+		}
+	}()
+	Get[*pool](c)
+}
+
+func TestGetOrPut_ConstructsOnce(t *testing.T) {
+	c := NewContainer()
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	results := make([]*pool, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = GetOrPut(c, func() *pool {
+				calls.Add(1)
+				return &pool{dsn: "postgres://"}
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("constructor ran %d times, want 1", got)
+	}
+	for _, p := range results {
+		if p != results[0] {
+			t.Fatal("GetOrPut returned different instances")
+		}
+	}
+	if Get[*pool](c) != results[0] {
+		t.Error("Get returned a different instance than GetOrPut stored")
+	}
+}
+
+func TestGetOrPut_ReturnsExisting(t *testing.T) {
+	c := NewContainer()
+	existing := &pool{dsn: "mysql://"}
+	Put(c, existing)
+
+	got := GetOrPut(c, func() *pool {
+		t.Error("constructor ran for a registered value")
+		return nil
+	})
+	if got != existing {
+		t.Errorf("GetOrPut() = %v, want registered value", got)
+	}
+}
+
+// mapContainer is a Container implemented outside the package, without
+// GetOrSetter.
+type mapContainer struct {
+	mu sync.Mutex
+	m  map[any]any
+}
+
+func (c *mapContainer) Set(key, val any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = val
+}
+
+func (c *mapContainer) Get(key any) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[key]
+	return v, ok
+}
+
+func (c *mapContainer) MustGet(key any) any {
+	v, _ := c.Get(key)
+	return v
+}
+
+func TestGetOrPut_FactoryUsesContainer(t *testing.T) {
+	type cache struct{ pool *pool }
+	c := NewContainer()
+
+	done := make(chan *cache, 1)
+	go func() {
+		done <- GetOrPut(c, func() *cache {
+			return &cache{pool: GetOrPut(c, func() *pool { return &pool{dsn: "postgres://"} })}
+		})
+	}()
+	select {
+	case got := <-done:
+		if got.pool != Get[*pool](c) {
+			t.Error("factory's GetOrPut result wasn't registered")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("GetOrPut deadlocked when its factory used the container")
+	}
+}
+
+func TestGetOrPut_WithoutGetOrSetter(t *testing.T) {
+	c := &mapContainer{m: map[any]any{}}
+	first := GetOrPut(c, func() *pool { return &pool{dsn: "postgres://"} })
+	second := GetOrPut(c, func() *pool {
+		t.Error("constructor ran for a registered value")
+		return nil
+	})
+	if first == nil || second != first {
+		t.Errorf("GetOrPut() = %v then %v, want the same value", first, second)
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"io"
+	"slices"
+	"sync"
+)
+
+// Flusher is implemented by resources that buffer data, such as log
+// handlers or trace exporters, and must write it out before the process
+// exits.
+type Flusher interface {
+	Flush() error
+}
+
+// shutdownHooks holds the closers registered with OnShutdown.
+type shutdownHooks struct {
+	mu      sync.Mutex
+	closers []io.Closer
+}
+
+// OnShutdown registers closer to be closed by App.Run once every module
+// has stopped, after flushing it if it's also a Flusher. Closers run in
+// reverse registration order, so a resource is closed before the ones it
+// was built from:
+//
+//	core.Provide(c, func(c core.Container) (*sql.DB, error) {
+//	    db, err := sql.Open("postgres", core.Get[config.Root](c).DB.URL)
+//	    if err == nil {
+//	        core.OnShutdown(c, db)
+//	    }
+//	    return db, err
+//	})
+//
+// Only registered values are closed; values merely stored in the
+// container belong to whoever put them there.
+func OnShutdown(c Container, closer io.Closer) {
+	hooks := GetOrPut(c, func() *shutdownHooks { return &shutdownHooks{} })
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.closers = append(hooks.closers, closer)
+}
+
+// flushAll flushes and closes the closers registered in c with
+// OnShutdown, newest first, and returns the first error.
+func flushAll(c Container) error {
+	v, ok := c.Get(TypeKey[*shutdownHooks]{})
+	if !ok {
+		return nil
+	}
+	hooks := v.(*shutdownHooks)
+	hooks.mu.Lock()
+	closers := slices.Clone(hooks.closers)
+	hooks.mu.Unlock()
+
+	var firstErr error
+	for _, cl := range slices.Backward(closers) {
+		if f, ok := cl.(Flusher); ok {
+			if err := f.Flush(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if err := cl.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
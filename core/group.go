@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Group runs background goroutines on behalf of modules, errgroup-style.
+//
+// App seeds a Group into the container before Configure. Modules fetch it
+// with core.Get[*core.Group] and hand it their long-running work instead of
+// starting detached goroutines:
+//
+//	g := core.Get[*core.Group](c)
+//	g.Go(func(ctx context.Context) error {
+//	    return worker.Run(ctx)
+//	})
+//
+// The first non-nil error returned by a goroutine makes App initiate a
+// graceful shutdown, and Run returns that error. The context passed to each
+// goroutine is cancelled once the app has stopped its modules.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+	failed  chan struct{}
+}
+
+// NewGroup returns a Group whose goroutines receive a context derived from ctx.
+func NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+		failed: make(chan struct{}),
+	}
+}
+
+// Go runs fn on a new goroutine. If fn returns a non-nil error, the first
+// such error is recorded and Failed is closed.
+func (g *Group) Go(fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(g.ctx); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				close(g.failed)
+			})
+		}
+	}()
+}
+
+// Failed is closed when a goroutine first returns a non-nil error.
+func (g *Group) Failed() <-chan struct{} { return g.failed }
+
+// Err returns the first error returned by a goroutine, if any.
+func (g *Group) Err() error {
+	select {
+	case <-g.failed:
+		return g.err
+	default:
+		return nil
+	}
+}
+
+// Wait cancels the goroutines' context, waits for them to return, and
+// returns the first error.
+func (g *Group) Wait() error {
+	g.cancel()
+	g.wg.Wait()
+	return g.Err()
+}
+
+// WaitContext is Wait, giving up once ctx is done on goroutines that
+// ignore cancellation. They're then left running, and the error wraps
+// ctx.Err().
+func (g *Group) WaitContext(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() { done <- g.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("core: background goroutines still running: %w", ctx.Err())
+	}
+}
@@ -14,3 +14,55 @@ type Module interface {
 	// Stop gracefully stops the module.
 	Stop(ctx context.Context, c Container) error
 }
+
+// OptionalDependent is implemented by modules with soft dependencies: modules
+// they must start after if present, but can run without.
+//
+// For example, a module might start after "tracing" so spans are exported
+// when tracing is enabled, without failing when it isn't. Hard dependencies
+// belong in DependsOn, which stays strict.
+type OptionalDependent interface {
+	OptionalDependsOn() []string
+}
+
+// optionalDeps returns m's soft dependencies, or nil if it declares none.
+func optionalDeps(m Module) []string {
+	if od, ok := m.(OptionalDependent); ok {
+		return od.OptionalDependsOn()
+	}
+	return nil
+}
+
+// Requirer is implemented by modules that declare the container entries they
+// need. App verifies the declared entries are present before the module's
+// Configure, so they must be seeded into the container or put there by
+// modules it depends on. Run then fails before any module starts,
+// reporting all missing entries at once, instead of Configure panicking on
+// a core.Get.
+//
+// Keys are container keys, typically TypeKey values:
+//
+//	func (m *module) Requires() []any {
+//	    return []any{core.TypeKey[*slog.Logger]{}, core.TypeKey[*gin.Engine]{}}
+//	}
+type Requirer interface {
+	Requires() []any
+}
+
+// PreStopper is an optional hook for modules that need to act before any
+// module is stopped, e.g. a server that should stop accepting new work while
+// its dependencies are still available to drain in-flight requests.
+//
+// App calls PreStop on every module (in reverse order) before the Stop pass.
+// Modules that don't implement it are skipped.
+type PreStopper interface {
+	PreStop(ctx context.Context, c Container) error
+}
+
+// preStop invokes m's PreStop hook if it has one; otherwise it's a no-op.
+func preStop(ctx context.Context, m Module, c Container) error {
+	if ps, ok := m.(PreStopper); ok {
+		return ps.PreStop(ctx, c)
+	}
+	return nil
+}
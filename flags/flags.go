@@ -0,0 +1,227 @@
+// Package flags provides feature flags: the static ones from the features
+// section of the config, optionally overridden by flags polled from a
+// remote flag service.
+//
+// Handlers check flags through the Flags in the container:
+//
+//	if flags.From(c).IsEnabled("new-checkout") {
+//	    ...
+//	}
+//
+// A config with a remote URL polls it every interval:
+//
+//	features:
+//	  flags:
+//	    new-checkout: false
+//	  remote:
+//	    url: https://flags.internal/orders
+//	    interval: 15s
+//
+// The service must answer with a JSON object of flag names to booleans.
+// Flags it returns override the static ones; a failed poll is logged and
+// keeps the flags from the last successful one.
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/core"
+)
+
+const Name = "flags"
+
+// Defaults for the remote poll when the config leaves them zero.
+const (
+	DefaultInterval = 30 * time.Second
+	DefaultTimeout  = 5 * time.Second
+)
+
+// Flags is the current set of feature flags. It's safe for concurrent use;
+// updates replace the whole set at once, so readers never see a poll half
+// applied.
+type Flags struct {
+	static atomic.Pointer[map[string]bool]
+	remote atomic.Pointer[map[string]bool]
+}
+
+// From returns the Flags the flags module registered in c.
+func From(c core.Container) *Flags {
+	return core.Get[*Flags](c)
+}
+
+// IsEnabled reports whether the named feature is on. Unknown features are
+// off.
+func (f *Flags) IsEnabled(name string) bool {
+	if remote := f.remote.Load(); remote != nil {
+		if on, ok := (*remote)[name]; ok {
+			return on
+		}
+	}
+	if static := f.static.Load(); static != nil {
+		return (*static)[name]
+	}
+	return false
+}
+
+// All returns a copy of the current flags, remote ones overriding static.
+func (f *Flags) All() map[string]bool {
+	out := map[string]bool{}
+	if static := f.static.Load(); static != nil {
+		maps.Copy(out, *static)
+	}
+	if remote := f.remote.Load(); remote != nil {
+		maps.Copy(out, *remote)
+	}
+	return out
+}
+
+func (f *Flags) setStatic(m map[string]bool) {
+	m = maps.Clone(m)
+	f.static.Store(&m)
+}
+
+func (f *Flags) setRemote(m map[string]bool) {
+	f.remote.Store(&m)
+}
+
+type options struct {
+	client *http.Client
+}
+
+// Option configures the flags module.
+type Option func(*options)
+
+// WithHTTPClient polls the flag service with client instead of
+// http.DefaultClient, e.g. one whose transport adds credentials.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) { o.client = client }
+}
+
+type module struct {
+	opts  options
+	flags *Flags
+	stop  chan struct{}
+}
+
+// Module returns the flags module, which registers a *Flags in the
+// container. Static flags follow config reloads if there's a Manager.
+func Module(opts ...Option) core.Module {
+	m := &module{flags: &Flags{}}
+	for _, o := range opts {
+		o(&m.opts)
+	}
+	if m.opts.client == nil {
+		m.opts.client = http.DefaultClient
+	}
+	return m
+}
+
+func (m *module) Name() string        { return Name }
+func (m *module) DependsOn() []string { return nil }
+
+// Requires declares what the module gets from the container.
+func (m *module) Requires() []any {
+	return []any{core.TypeKey[config.Root]{}, core.TypeKey[*slog.Logger]{}}
+}
+
+func (m *module) Configure(c core.Container) error {
+	cfg := core.Get[config.Root](c)
+	m.flags.setStatic(cfg.Features.Flags)
+	if v, ok := c.Get(core.TypeKey[*config.Manager]{}); ok {
+		config.OnChange(v.(*config.Manager), func(_, cfg config.Root) {
+			m.flags.setStatic(cfg.Features.Flags)
+		})
+	}
+	core.Put(c, m.flags)
+	return nil
+}
+
+// Start fetches the remote flags once, then polls for them in the
+// background until Stop or the app's shutdown.
+func (m *module) Start(ctx context.Context, c core.Container) error {
+	remote := core.Get[config.Root](c).Features.Remote
+	if remote.URL == "" {
+		return nil
+	}
+	if remote.Interval <= 0 {
+		remote.Interval = DefaultInterval
+	}
+	if remote.Timeout <= 0 {
+		remote.Timeout = DefaultTimeout
+	}
+	l := core.Get[*slog.Logger](c)
+
+	m.refresh(ctx, l, remote)
+	m.stop = make(chan struct{})
+	stop := m.stop
+	core.Get[*core.Group](c).Go(func(ctx context.Context) error {
+		ticker := time.NewTicker(remote.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-stop:
+				return nil
+			case <-ticker.C:
+				m.refresh(ctx, l, remote)
+			}
+		}
+	})
+	return nil
+}
+
+// refresh fetches the remote flags, logging failures rather than
+// returning them: the app keeps running on the last flags it got.
+func (m *module) refresh(ctx context.Context, l *slog.Logger, remote config.RemoteFlagsConfig) {
+	fetchCtx, cancel := context.WithTimeout(ctx, remote.Timeout)
+	defer cancel()
+	flags, err := m.fetch(fetchCtx, remote.URL)
+	if err != nil {
+		if ctx.Err() == nil { // not shutting down
+			l.Warn("feature flags fetch failed", "url", remote.URL, "error", err)
+		}
+		return
+	}
+	m.flags.setRemote(flags)
+}
+
+func (m *module) fetch(ctx context.Context, url string) (map[string]bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := m.opts.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var flags map[string]bool
+	if err := json.NewDecoder(resp.Body).Decode(&flags); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	if flags == nil {
+		flags = map[string]bool{}
+	}
+	return flags, nil
+}
+
+func (m *module) Stop(_ context.Context, _ core.Container) error {
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+	return nil
+}
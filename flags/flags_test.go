@@ -0,0 +1,162 @@
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/core"
+)
+
+// flagServer is a mock flag service whose answer tests change between
+// polls.
+type flagServer struct {
+	mu     sync.Mutex
+	flags  map[string]bool
+	status int
+	polls  int
+}
+
+func (s *flagServer) set(status int, flags map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status, s.flags = status, flags
+}
+
+func (s *flagServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.polls++
+	if s.status != http.StatusOK {
+		w.WriteHeader(s.status)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(s.flags)
+}
+
+// startModule configures and starts the flags module against root, and
+// stops it when the test ends.
+func startModule(t *testing.T, root config.Root) *Flags {
+	t.Helper()
+	c := core.NewContainer()
+	core.Put(c, root)
+	core.Put(c, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	group := core.NewGroup(context.Background())
+	core.Put(c, group)
+
+	m := Module()
+	if err := m.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := m.Start(context.Background(), c); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = m.Stop(context.Background(), c)
+		_ = group.Wait()
+	})
+	return From(c)
+}
+
+// eventually polls cond until it holds or a second passes.
+func eventually(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestModule_StaticFlags(t *testing.T) {
+	f := startModule(t, config.Root{
+		Features: config.FeaturesConfig{Flags: map[string]bool{"new-checkout": true, "beta": false}},
+	})
+
+	if !f.IsEnabled("new-checkout") {
+		t.Error("IsEnabled(new-checkout) = false, want true")
+	}
+	if f.IsEnabled("beta") || f.IsEnabled("unknown") {
+		t.Error("IsEnabled() = true for a disabled or unknown flag")
+	}
+}
+
+func TestModule_RemoteFlagsUpdateAcrossPolls(t *testing.T) {
+	srv := &flagServer{}
+	srv.set(http.StatusOK, map[string]bool{"new-checkout": true})
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	f := startModule(t, config.Root{
+		Features: config.FeaturesConfig{
+			Flags:  map[string]bool{"new-checkout": false, "beta": true},
+			Remote: config.RemoteFlagsConfig{URL: ts.URL, Interval: 10 * time.Millisecond},
+		},
+	})
+
+	// The first fetch happens during Start and overrides the static flag.
+	if !f.IsEnabled("new-checkout") {
+		t.Error("IsEnabled(new-checkout) = false after start, want true from the flag service")
+	}
+	if !f.IsEnabled("beta") {
+		t.Error("IsEnabled(beta) = false, want static flag kept when the service doesn't set it")
+	}
+
+	srv.set(http.StatusOK, map[string]bool{"new-checkout": false, "beta": false})
+	if !eventually(t, func() bool { return !f.IsEnabled("new-checkout") && !f.IsEnabled("beta") }) {
+		t.Errorf("flags = %v after the service changed, want both off", f.All())
+	}
+}
+
+func TestModule_RemoteFetchErrorKeepsLastFlags(t *testing.T) {
+	srv := &flagServer{}
+	srv.set(http.StatusOK, map[string]bool{"new-checkout": true})
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	f := startModule(t, config.Root{
+		Features: config.FeaturesConfig{
+			Remote: config.RemoteFlagsConfig{URL: ts.URL, Interval: 10 * time.Millisecond},
+		},
+	})
+
+	srv.set(http.StatusInternalServerError, nil)
+	srv.mu.Lock()
+	polls := srv.polls
+	srv.mu.Unlock()
+	eventually(t, func() bool {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		return srv.polls > polls+2
+	})
+
+	if !f.IsEnabled("new-checkout") {
+		t.Error("IsEnabled(new-checkout) = false after failed polls, want the last fetched value")
+	}
+}
+
+func TestModule_RemoteUnavailableAtStart(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	f := startModule(t, config.Root{
+		Features: config.FeaturesConfig{
+			Flags:  map[string]bool{"beta": true},
+			Remote: config.RemoteFlagsConfig{URL: ts.URL, Interval: time.Hour},
+		},
+	})
+
+	if got := f.All(); len(got) != 1 || !got["beta"] {
+		t.Errorf("All() = %v, want the static flags", got)
+	}
+}
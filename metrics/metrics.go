@@ -0,0 +1,92 @@
+// Package metrics lets modules register Prometheus metrics on the app's
+// shared registry, the one the actuator serves at /metrics.
+//
+// The helpers are idempotent: registering a metric that's already
+// registered with the same name, help and labels returns the existing one,
+// so modules (or several instances of one) can declare the metrics they
+// use without coordinating:
+//
+//	requests := metrics.CounterVec(c, prometheus.CounterOpts{
+//	    Name: "orders_requests_total",
+//	    Help: "Order API requests.",
+//	}, "status")
+//	requests.WithLabelValues("200").Inc()
+//
+// Registering a different metric under a taken name panics, as
+// prometheus.MustRegister does.
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"github.com/skekre98/genever/core"
+)
+
+// Registry returns the shared registry in c, creating it with the Go and
+// process collectors if no module has yet.
+func Registry(c core.Container) *prometheus.Registry {
+	return core.GetOrPut(c, func() *prometheus.Registry {
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+		return reg
+	})
+}
+
+// Counter registers a counter on the shared registry, or returns the one
+// already registered with the same options.
+func Counter(c core.Container, opts prometheus.CounterOpts) prometheus.Counter {
+	return register(c, prometheus.NewCounter(opts))
+}
+
+// CounterVec is Counter for a counter partitioned by labels.
+func CounterVec(c core.Container, opts prometheus.CounterOpts, labels ...string) *prometheus.CounterVec {
+	return register(c, prometheus.NewCounterVec(opts, labels))
+}
+
+// Gauge registers a gauge on the shared registry, or returns the one
+// already registered with the same options.
+func Gauge(c core.Container, opts prometheus.GaugeOpts) prometheus.Gauge {
+	return register(c, prometheus.NewGauge(opts))
+}
+
+// GaugeVec is Gauge for a gauge partitioned by labels.
+func GaugeVec(c core.Container, opts prometheus.GaugeOpts, labels ...string) *prometheus.GaugeVec {
+	return register(c, prometheus.NewGaugeVec(opts, labels))
+}
+
+// Histogram registers a histogram on the shared registry, or returns the
+// one already registered with the same options.
+func Histogram(c core.Container, opts prometheus.HistogramOpts) prometheus.Histogram {
+	return register(c, prometheus.NewHistogram(opts))
+}
+
+// HistogramVec is Histogram for a histogram partitioned by labels.
+func HistogramVec(c core.Container, opts prometheus.HistogramOpts, labels ...string) *prometheus.HistogramVec {
+	return register(c, prometheus.NewHistogramVec(opts, labels))
+}
+
+// register registers col, returning the existing collector instead if an
+// identical one is registered.
+func register[T prometheus.Collector](c core.Container, col T) T {
+	err := Registry(c).Register(col)
+	if err == nil {
+		return col
+	}
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		// Compare concrete types: a gauge satisfies prometheus.Counter.
+		if existing, ok := already.ExistingCollector.(T); ok && reflect.TypeOf(existing) == reflect.TypeOf(col) {
+			return existing
+		}
+		err = fmt.Errorf("already registered as %T", already.ExistingCollector)
+	}
+	panic(fmt.Errorf("metrics: %w", err))
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/skekre98/genever/core"
+)
+
+func TestRegister_Idempotent(t *testing.T) {
+	c := core.NewContainer()
+	opts := prometheus.HistogramOpts{Name: "latency_seconds", Help: "Latency."}
+
+	a := HistogramVec(c, opts, "route")
+	b := HistogramVec(c, opts, "route")
+	if a != b {
+		t.Error("second HistogramVec() returned a new collector, want the registered one")
+	}
+	if Registry(c) != core.Get[*prometheus.Registry](c) {
+		t.Error("Registry() is not the container's registry")
+	}
+}
+
+func TestRegister_ConflictPanics(t *testing.T) {
+	c := core.NewContainer()
+	Gauge(c, prometheus.GaugeOpts{Name: "queue_depth", Help: "Queue depth."})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a different metric under a taken name didn't panic")
+		}
+	}()
+	Counter(c, prometheus.CounterOpts{Name: "queue_depth", Help: "Queue depth."})
+}
@@ -0,0 +1,130 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type testOrder struct {
+	Item     string      `json:"item" validate:"required"`
+	Quantity int         `json:"quantity" validate:"min=1,max=100"`
+	Address  testAddress `json:"address"`
+}
+
+type testSearch struct {
+	Query string `form:"q" validate:"required"`
+	Limit int    `form:"limit" validate:"omitempty,max=50"`
+}
+
+type problemBody struct {
+	Status int              `json:"status"`
+	Detail string           `json:"detail"`
+	Errors []FieldViolation `json:"errors"`
+}
+
+func bindRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/orders", func(c *gin.Context) {
+		var req testOrder
+		if !BindJSON(c, &req) {
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+	r.GET("/search", func(c *gin.Context) {
+		var req testSearch
+		if !BindQuery(c, &req) {
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+	return r
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) problemBody {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+	var body problemBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid problem body %s: %v", w.Body.String(), err)
+	}
+	return body
+}
+
+func TestBindJSON_Valid(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders",
+		strings.NewReader(`{"item": "book", "quantity": 2, "address": {"city": "Oslo"}}`))
+	bindRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+}
+
+func TestBindJSON_ValidationProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders",
+		strings.NewReader(`{"quantity": 0, "address": {}}`))
+	bindRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	body := decodeProblem(t, w)
+	want := []FieldViolation{
+		{Field: "item", Rule: "required"},
+		{Field: "quantity", Rule: "min", Param: "1"},
+		{Field: "address.city", Rule: "required"},
+	}
+	if !reflect.DeepEqual(body.Errors, want) {
+		t.Errorf("errors = %+v, want %+v", body.Errors, want)
+	}
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"item":`))
+	bindRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if body := decodeProblem(t, w); len(body.Errors) != 0 || body.Detail == "" {
+		t.Errorf("malformed body problem = %+v, want detail without field errors", body)
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	w := httptest.NewRecorder()
+	bindRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search?q=shoes&limit=10", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("valid query status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	w = httptest.NewRecorder()
+	bindRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search?limit=500", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid query status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	want := []FieldViolation{
+		{Field: "q", Rule: "required"},
+		{Field: "limit", Rule: "max", Param: "50"},
+	}
+	if body := decodeProblem(t, w); !reflect.DeepEqual(body.Errors, want) {
+		t.Errorf("errors = %+v, want %+v", body.Errors, want)
+	}
+}
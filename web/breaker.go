@@ -0,0 +1,125 @@
+package web
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CircuitBreakerOptions configures CircuitBreaker.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens a
+	// route's breaker. Defaults to 5.
+	FailureThreshold int
+
+	// OpenDuration is how long an open breaker rejects requests before
+	// letting a probe through. Defaults to 30s.
+	OpenDuration time.Duration
+
+	// IsFailure classifies a response status as a failure. Defaults to
+	// status >= 500.
+	IsFailure func(status int) bool
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker tracks one route.
+type breaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// CircuitBreaker short-circuits routes whose handlers keep failing, e.g.
+// because a downstream they call is down.
+//
+// Each route (method and path pattern) has its own breaker. After
+// FailureThreshold consecutive failures the breaker opens and requests get a
+// 503 problem+json without reaching the handler. Once OpenDuration has
+// passed, a single probe request is let through: success closes the breaker,
+// failure opens it again.
+//
+// Apply it to a group of routes that depend on flaky downstreams:
+//
+//	api := r.Group("/api", web.CircuitBreaker(web.CircuitBreakerOptions{
+//	    FailureThreshold: 3,
+//	    OpenDuration:     10 * time.Second,
+//	}))
+func CircuitBreaker(opts CircuitBreakerOptions) Handler {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.OpenDuration <= 0 {
+		opts.OpenDuration = 30 * time.Second
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(status int) bool { return status >= http.StatusInternalServerError }
+	}
+
+	var (
+		mu       sync.Mutex
+		breakers = map[string]*breaker{}
+	)
+
+	return func(c *gin.Context) {
+		key := c.Request.Method + " " + c.FullPath()
+
+		mu.Lock()
+		b, ok := breakers[key]
+		if !ok {
+			b = &breaker{}
+			breakers[key] = b
+		}
+		switch b.state {
+		case breakerOpen:
+			if time.Since(b.openedAt) < opts.OpenDuration {
+				mu.Unlock()
+				Problem(c, http.StatusServiceUnavailable, "circuit breaker open")
+				return
+			}
+			// Cooldown over: this request is the probe.
+			b.state = breakerHalfOpen
+		case breakerHalfOpen:
+			// A probe is already in flight.
+			mu.Unlock()
+			Problem(c, http.StatusServiceUnavailable, "circuit breaker open")
+			return
+		}
+		mu.Unlock()
+
+		// Record the outcome even if the handler panics, so a probe that
+		// blows up doesn't leave the breaker half-open forever.
+		panicked := true
+		defer func() {
+			failed := panicked || opts.IsFailure(c.Writer.Status())
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case !failed:
+				b.state = breakerClosed
+				b.failures = 0
+			case b.state == breakerHalfOpen:
+				b.state = breakerOpen
+				b.openedAt = time.Now()
+			default:
+				b.failures++
+				if b.failures >= opts.FailureThreshold {
+					b.state = breakerOpen
+					b.openedAt = time.Now()
+				}
+			}
+		}()
+
+		c.Next()
+		panicked = false
+	}
+}
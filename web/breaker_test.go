@@ -0,0 +1,106 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serve(r http.Handler, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var downstreamUp atomic.Bool
+	var calls atomic.Int32
+
+	r := gin.New()
+	r.Use(CircuitBreaker(CircuitBreakerOptions{
+		FailureThreshold: 3,
+		OpenDuration:     50 * time.Millisecond,
+	}))
+	r.GET("/orders", func(c *gin.Context) {
+		calls.Add(1)
+		if !downstreamUp.Load() {
+			c.Status(http.StatusBadGateway)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// Drive failures up to the threshold.
+	for i := 0; i < 3; i++ {
+		if w := serve(r, http.MethodGet, "/orders"); w.Code != http.StatusBadGateway {
+			t.Fatalf("request %d = %d, want %d", i, w.Code, http.StatusBadGateway)
+		}
+	}
+
+	// The breaker is open: the handler is not called.
+	w := serve(r, http.MethodGet, "/orders")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("open breaker = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body["status"] != float64(503) {
+		t.Errorf("problem body = %s", w.Body.String())
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("handler calls = %d, want 3", got)
+	}
+
+	// Other routes have their own breaker.
+	if w := serve(r, http.MethodGet, "/health"); w.Code != http.StatusOK {
+		t.Errorf("unrelated route = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	// After the cooldown a failing probe re-opens the breaker.
+	time.Sleep(60 * time.Millisecond)
+	if w := serve(r, http.MethodGet, "/orders"); w.Code != http.StatusBadGateway {
+		t.Fatalf("failed probe = %d, want %d", w.Code, http.StatusBadGateway)
+	}
+	if w := serve(r, http.MethodGet, "/orders"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("after failed probe = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	// Once the downstream recovers, a successful probe closes it.
+	downstreamUp.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if w := serve(r, http.MethodGet, "/orders"); w.Code != http.StatusOK {
+			t.Fatalf("after recovery request %d = %d, want %d", i, w.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := http.StatusInternalServerError
+	r := gin.New()
+	r.Use(CircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2, OpenDuration: time.Minute}))
+	r.GET("/", func(c *gin.Context) { c.Status(status) })
+
+	serve(r, http.MethodGet, "/")
+	status = http.StatusOK
+	serve(r, http.MethodGet, "/")
+	status = http.StatusInternalServerError
+	serve(r, http.MethodGet, "/")
+
+	// Failures weren't consecutive, so the breaker is still closed.
+	if w := serve(r, http.MethodGet, "/"); w.Code != http.StatusInternalServerError {
+		t.Errorf("request = %d, want handler's %d", w.Code, http.StatusInternalServerError)
+	}
+}
@@ -1,6 +1,10 @@
 package web
 
-import "time"
+import (
+	"time"
+
+	"github.com/skekre98/genever/config"
+)
 
 type TLSConfig struct {
 	Enabled  bool   `yaml:"enabled" json:"enabled"`
@@ -20,8 +24,28 @@ type Server struct {
 type Options struct {
 	// Called during Configure to register routes.
 	Routes []func(r Router)
+	// Routes declared as data, registered before the Routes callbacks.
+	RouteTable []Route
 	// Optional additional middlewares.
 	Middlewares []Handler
+	// Number of slowest requests to retain; zero disables the slow log.
+	SlowLogCapacity int
+	// How long a request stays in the slow log; zero means
+	// DefaultSlowLogWindow.
+	SlowLogWindow time.Duration
+	// Which requests the built-in access log records.
+	AccessLog AccessLogOptions
+	// Base URI for problem+json type members; empty uses "about:blank".
+	ProblemTypeBase string
+	// Handlers for unmatched paths and methods; nil uses problem+json
+	// responders.
+	NotFound         Handler
+	MethodNotAllowed Handler
+	// JSON implementation of responses and bodies; nil uses gin's (see
+	// WithJSONCodec).
+	JSONCodec JSONCodec
+	// CORS policies by path prefix; server.cors overrides (see WithCORS).
+	CORS map[string]config.CORSPolicy
 }
 
 type Option func(*Options)
@@ -30,6 +54,50 @@ func WithRoutes(f func(r Router)) Option {
 	return func(o *Options) { o.Routes = append(o.Routes, f) }
 }
 
+// WithRouteTable registers routes declared as data. Unlike WithRoutes
+// callbacks, a route table can be inspected and tested without an engine;
+// keep WithRoutes for routes that depend on the container.
+func WithRouteTable(routes ...Route) Option {
+	return func(o *Options) { o.RouteTable = append(o.RouteTable, routes...) }
+}
+
 func WithMiddlewares(m ...Handler) Option {
 	return func(o *Options) { o.Middlewares = append(o.Middlewares, m...) }
 }
+
+// WithSlowLog retains the capacity slowest recent requests, exposed by the
+// actuator at {basePath}/slowlog.
+func WithSlowLog(capacity int) Option {
+	return func(o *Options) { o.SlowLogCapacity = capacity }
+}
+
+// WithSlowLogWindow sets how long a request stays in the slow log; the
+// default is DefaultSlowLogWindow.
+func WithSlowLogWindow(window time.Duration) Option {
+	return func(o *Options) { o.SlowLogWindow = window }
+}
+
+// WithAccessLog configures the built-in access log, e.g. to skip OPTIONS
+// and HEAD requests or sample high-traffic routes.
+func WithAccessLog(opts AccessLogOptions) Option {
+	return func(o *Options) { o.AccessLog = opts }
+}
+
+// WithProblemTypeBase sets the base documentation URI for problem+json
+// responses, so their type is e.g. https://docs.example.com/errors/not-found.
+func WithProblemTypeBase(uri string) Option {
+	return func(o *Options) { o.ProblemTypeBase = uri }
+}
+
+// WithNotFound sets the handler for requests that match no route. The
+// default responds with a 404 problem.
+func WithNotFound(h Handler) Option {
+	return func(o *Options) { o.NotFound = h }
+}
+
+// WithMethodNotAllowed sets the handler for requests whose path matches a
+// route registered for other methods only. The default responds with a 405
+// problem.
+func WithMethodNotAllowed(h Handler) Option {
+	return func(o *Options) { o.MethodNotAllowed = h }
+}
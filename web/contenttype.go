@@ -0,0 +1,40 @@
+package web
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireContentType rejects requests whose body has a media type outside
+// types (e.g. "application/json") with a 415 problem. Parameters such as
+// charset are ignored when matching. Requests without a body pass through,
+// so it can be installed on a group that also serves GET and DELETE.
+func RequireContentType(types ...string) Handler {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	return func(c *gin.Context) {
+		if !hasBody(c.Request) {
+			c.Next()
+			return
+		}
+		ct := c.GetHeader("Content-Type")
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || !allowed[mt] {
+			Problem(c, http.StatusUnsupportedMediaType,
+				"content type "+strconv.Quote(ct)+" is not supported; use "+strings.Join(types, ", "))
+			return
+		}
+		c.Next()
+	}
+}
+
+// hasBody reports whether r carries a request body.
+func hasBody(r *http.Request) bool {
+	return r.ContentLength > 0 || len(r.TransferEncoding) > 0
+}
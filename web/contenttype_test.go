@@ -0,0 +1,60 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func contentTypeRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	api := r.Group("/orders", RequireContentType("application/json"))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	api.POST("", ok)
+	api.GET("", ok)
+	api.DELETE("/:id", ok)
+	return r
+}
+
+func TestRequireContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"accepted", http.MethodPost, "/orders", "application/json", `{}`, http.StatusNoContent},
+		{"accepted with charset", http.MethodPost, "/orders", "Application/JSON; charset=utf-8", `{}`, http.StatusNoContent},
+		{"rejected", http.MethodPost, "/orders", "text/plain", "hi", http.StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPost, "/orders", "", "hi", http.StatusUnsupportedMediaType},
+		{"bodyless get", http.MethodGet, "/orders", "", "", http.StatusNoContent},
+		{"bodyless delete", http.MethodDelete, "/orders/1", "", "", http.StatusNoContent},
+	}
+
+	r := contentTypeRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusUnsupportedMediaType {
+				if body := decodeProblem(t, w); body.Status != http.StatusUnsupportedMediaType {
+					t.Errorf("problem status = %d, want 415", body.Status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package web
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/config"
+)
+
+// defaultCORSMethods are allowed when a policy lists no AllowMethods.
+var defaultCORSMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost,
+	http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// WithCORS sets the CORS policy of the routes under prefix, e.g. a group
+// registered with r.Group(prefix). A policy for the same prefix in
+// server.cors replaces it. See config.ServerConfig.CORS.
+func WithCORS(prefix string, policy config.CORSPolicy) Option {
+	return func(o *Options) {
+		if o.CORS == nil {
+			o.CORS = make(map[string]config.CORSPolicy)
+		}
+		o.CORS[prefix] = policy
+	}
+}
+
+// corsPolicies holds the current CORS policies by path prefix.
+type corsPolicies struct {
+	prefixes []string // longest first
+	policies map[string]config.CORSPolicy
+}
+
+// corsFrom combines the policies of the module options and of the config,
+// which take precedence. A policy allowing any origin ("*") with
+// credentials is an error: it would let every site make credentialed
+// requests.
+func corsFrom(opts, cfg map[string]config.CORSPolicy) (*corsPolicies, error) {
+	policies := make(map[string]config.CORSPolicy, len(opts)+len(cfg))
+	for prefix, p := range opts {
+		policies[normalizePrefix(prefix)] = p
+	}
+	for prefix, p := range cfg {
+		policies[normalizePrefix(prefix)] = p
+	}
+	prefixes := slices.Collect(maps.Keys(policies))
+	slices.SortFunc(prefixes, func(a, b string) int { return len(b) - len(a) })
+	for _, prefix := range prefixes {
+		if p := policies[prefix]; p.AllowCredentials && slices.Contains(p.AllowOrigins, "*") {
+			if prefix == "" {
+				prefix = "/"
+			}
+			return nil, fmt.Errorf("web: cors policy for %q allows any origin with credentials; list the allowed origins instead", prefix)
+		}
+	}
+	return &corsPolicies{prefixes: prefixes, policies: policies}, nil
+}
+
+// normalizePrefix trims the trailing slash of prefix, so "/" and "" match
+// every path and "/public/" matches like "/public".
+func normalizePrefix(prefix string) string {
+	return strings.TrimSuffix(prefix, "/")
+}
+
+// lookup returns the policy of the longest prefix that path is at or under.
+func (p *corsPolicies) lookup(path string) (config.CORSPolicy, bool) {
+	for _, prefix := range p.prefixes {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && (rest == "" || rest[0] == '/') {
+			return p.policies[prefix], true
+		}
+	}
+	return config.CORSPolicy{}, false
+}
+
+// routeCORS applies the policy in cur matching each request's path. It
+// runs for unmatched routes too, so it answers preflights for routes
+// without an OPTIONS handler. cur is swapped when the config reloads.
+func routeCORS(cur *atomic.Pointer[corsPolicies]) Handler {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		policy, ok := cur.Load().lookup(c.Request.URL.Path)
+		if !ok {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		allowAny := slices.Contains(policy.AllowOrigins, "*")
+		if !allowAny && !slices.Contains(policy.AllowOrigins, origin) {
+			if preflight {
+				c.Abort()
+				Problem(c, http.StatusForbidden, "origin "+origin+" is not allowed")
+				return
+			}
+			c.Next() // without CORS headers the browser hides the response
+			return
+		}
+
+		if allowAny {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if policy.AllowCredentials { // never with "*", see corsFrom
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if len(policy.ExposeHeaders) > 0 {
+				c.Header("Access-Control-Expose-Headers", strings.Join(policy.ExposeHeaders, ", "))
+			}
+			c.Next()
+			return
+		}
+
+		methods := policy.AllowMethods
+		if len(methods) == 0 {
+			methods = defaultCORSMethods
+		}
+		c.Header("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if len(policy.AllowHeaders) > 0 {
+			c.Header("Access-Control-Allow-Headers", strings.Join(policy.AllowHeaders, ", "))
+		} else if h := c.GetHeader("Access-Control-Request-Headers"); h != "" {
+			c.Header("Access-Control-Allow-Headers", h)
+		}
+		if policy.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/core"
+)
+
+// serveFrom serves a request with an Origin header, and for a preflight
+// (method OPTIONS) the method it asks for.
+func serveFrom(r http.Handler, method, path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestModule_CORS(t *testing.T) {
+	c := core.NewContainer()
+	root := testRoot()
+	root.Server.CORS = map[string]config.CORSPolicy{
+		"/public":   {AllowOrigins: []string{"*"}},
+		"/internal": {AllowOrigins: []string{"https://admin.example.com"}, AllowCredentials: true},
+	}
+	core.Put(c, root)
+	core.Put(c, testLogger())
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	mod := Module(
+		WithCORS("/internal", config.CORSPolicy{AllowOrigins: []string{"https://replaced.example.com"}}),
+		WithCORS("/partners/", config.CORSPolicy{AllowOrigins: []string{"https://partner.example.com"}}),
+		WithRoutes(func(r Router) {
+			r.GET("/public/items", ok)
+			r.GET("/internal/stats", ok)
+			r.GET("/partners/orders", ok)
+			r.GET("/health", ok)
+		}),
+	)
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := Engine(c)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{"public any origin", http.MethodGet, "/public/items", "https://evil.example.com", http.StatusOK, "*"},
+		{"internal known origin", http.MethodGet, "/internal/stats", "https://admin.example.com", http.StatusOK, "https://admin.example.com"},
+		{"internal unknown origin", http.MethodGet, "/internal/stats", "https://evil.example.com", http.StatusOK, ""},
+		{"config replaces option", http.MethodGet, "/internal/stats", "https://replaced.example.com", http.StatusOK, ""},
+		{"option policy", http.MethodGet, "/partners/orders", "https://partner.example.com", http.StatusOK, "https://partner.example.com"},
+		{"outside every group", http.MethodGet, "/health", "https://admin.example.com", http.StatusOK, ""},
+		{"public preflight", http.MethodOptions, "/public/items", "https://evil.example.com", http.StatusNoContent, "*"},
+		{"internal preflight known origin", http.MethodOptions, "/internal/stats", "https://admin.example.com", http.StatusNoContent, "https://admin.example.com"},
+		{"internal preflight unknown origin", http.MethodOptions, "/internal/stats", "https://evil.example.com", http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveFrom(engine, tt.method, tt.path, tt.origin)
+			if w.Code != tt.wantStatus {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+		})
+	}
+
+	w := serveFrom(engine, http.MethodOptions, "/internal/stats", "https://admin.example.com")
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); got != "Authorization" {
+		t.Errorf("Access-Control-Allow-Headers = %q, want the requested Authorization", got)
+	}
+}
+
+func TestModule_CORSAnyOriginWithCredentials(t *testing.T) {
+	c := core.NewContainer()
+	root := testRoot()
+	root.Server.CORS = map[string]config.CORSPolicy{
+		"/": {AllowOrigins: []string{"*"}, AllowCredentials: true},
+	}
+	core.Put(c, root)
+	core.Put(c, testLogger())
+
+	err := Module().Configure(c)
+	if err == nil || !strings.Contains(err.Error(), `"/" allows any origin with credentials`) {
+		t.Errorf("Configure() error = %v, want the any-origin-with-credentials policy rejected", err)
+	}
+}
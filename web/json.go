@@ -0,0 +1,121 @@
+package web
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	ginjson "github.com/gin-gonic/gin/codec/json"
+)
+
+// JSONCodec is a JSON implementation, in gin's codec interface.
+type JSONCodec = ginjson.Core
+
+// WithJSONCodec makes the web module's responses and request bodies use
+// codec, e.g. jsoniter or an encoder configured for the app's time format.
+//
+// The codec is scoped to the module's engine: it applies to problem
+// responses, BindJSON and handlers rendering with JSON, not to c.JSON,
+// which always uses gin's process-wide codec. It must be safe for
+// concurrent use. Measure before swapping encoders for speed:
+// encoding/json is rarely the bottleneck, and alternatives differ in edge
+// cases (HTML escaping, map key order, invalid UTF-8) that clients may
+// notice. To change only how responses are rendered, use MarshalFunc.
+func WithJSONCodec(codec JSONCodec) Option {
+	return func(o *Options) { o.JSONCodec = codec }
+}
+
+// jsonCodec is the request value holding the module's JSONCodec.
+type jsonCodec struct{ JSONCodec }
+
+// useJSONCodec makes codec the JSON implementation of the requests it
+// handles.
+func useJSONCodec(codec JSONCodec) Handler {
+	return func(c *gin.Context) {
+		SetValue(c, jsonCodec{codec})
+		c.Next()
+	}
+}
+
+// codecOf returns the request's JSON codec: the module's, or gin's.
+func codecOf(c Ctx) JSONCodec {
+	if codec, ok := GetValue[jsonCodec](c); ok {
+		return codec.JSONCodec
+	}
+	return ginjson.API
+}
+
+// JSON renders obj as the JSON response body with the web module's codec
+// (see WithJSONCodec), like c.JSON otherwise.
+func JSON(c Ctx, code int, obj any) {
+	c.Render(code, jsonRender{codec: codecOf(c), data: obj})
+}
+
+// jsonRender is gin's render.JSON with a chosen codec.
+type jsonRender struct {
+	codec JSONCodec
+	data  any
+}
+
+func (r jsonRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	b, err := r.codec.Marshal(r.data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func (r jsonRender) WriteContentType(w http.ResponseWriter) {
+	if header := w.Header(); len(header["Content-Type"]) == 0 {
+		header["Content-Type"] = []string{"application/json; charset=utf-8"}
+	}
+}
+
+// MarshalFunc is a JSONCodec that renders with the function it wraps and
+// decodes with gin's default codec, for customizing responses only:
+//
+//	web.WithJSONCodec(web.MarshalFunc(func(v any) ([]byte, error) {
+//	    return jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(v)
+//	}))
+type MarshalFunc func(v any) ([]byte, error)
+
+// defaultJSONCodec is gin's codec.
+var defaultJSONCodec = ginjson.API
+
+// Marshal calls f.
+func (f MarshalFunc) Marshal(v any) ([]byte, error) { return f(v) }
+
+// MarshalIndent calls f, ignoring the indentation.
+func (f MarshalFunc) MarshalIndent(v any, _, _ string) ([]byte, error) { return f(v) }
+
+// NewEncoder returns an encoder writing f's output, newline-terminated.
+func (f MarshalFunc) NewEncoder(w io.Writer) ginjson.Encoder { return &marshalEncoder{f: f, w: w} }
+
+// Unmarshal uses gin's default codec.
+func (f MarshalFunc) Unmarshal(data []byte, v any) error {
+	return defaultJSONCodec.Unmarshal(data, v)
+}
+
+// NewDecoder uses gin's default codec.
+func (f MarshalFunc) NewDecoder(r io.Reader) ginjson.Decoder {
+	return defaultJSONCodec.NewDecoder(r)
+}
+
+type marshalEncoder struct {
+	f MarshalFunc
+	w io.Writer
+}
+
+// SetEscapeHTML is a no-op; escaping is up to the MarshalFunc.
+func (e *marshalEncoder) SetEscapeHTML(bool) {}
+
+func (e *marshalEncoder) Encode(v any) error {
+	b, err := e.f(v)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
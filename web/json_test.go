@@ -0,0 +1,77 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	ginjson "github.com/gin-gonic/gin/codec/json"
+
+	"github.com/skekre98/genever/core"
+)
+
+func TestModule_JSONCodec(t *testing.T) {
+	prev := ginjson.API
+
+	calls := 0
+	codec := MarshalFunc(func(v any) ([]byte, error) {
+		calls++
+		return json.Marshal(map[string]any{"wrapped": v})
+	})
+
+	c := core.NewContainer()
+	core.Put(c, testRoot())
+	core.Put(c, testLogger())
+	mod := Module(WithJSONCodec(codec), WithRoutes(func(r Router) {
+		r.GET("/orders", func(c *gin.Context) {
+			JSON(c, http.StatusOK, gin.H{"id": 1})
+		})
+		r.POST("/orders", func(c *gin.Context) {
+			var req struct {
+				ID int `json:"id"`
+			}
+			if BindJSON(c, &req) {
+				JSON(c, http.StatusCreated, req.ID)
+			}
+		})
+	}))
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if !reflect.DeepEqual(ginjson.API, prev) {
+		t.Error("Configure() replaced gin's process-wide codec")
+	}
+
+	w := serve(Engine(c), http.MethodGet, "/orders")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /orders = %d, want %d", w.Code, http.StatusOK)
+	}
+	if calls != 1 {
+		t.Errorf("codec called %d times, want 1", calls)
+	}
+	if got, want := w.Body.String(), `{"wrapped":{"id":1}}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+
+	w = httptest.NewRecorder()
+	Engine(c).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":7}`)))
+	if got, want := w.Body.String(), `{"wrapped":7}`; w.Code != http.StatusCreated || got != want {
+		t.Errorf("POST /orders = %d %s, want %d %s", w.Code, got, http.StatusCreated, want)
+	}
+
+	w = serve(Engine(c), http.MethodGet, "/missing")
+	if !strings.HasPrefix(w.Body.String(), `{"wrapped":`) || w.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("problem = %s (%s), want it rendered with the codec", w.Body.String(), w.Header().Get("Content-Type"))
+	}
+
+	// Other engines keep gin's codec.
+	other := gin.New()
+	other.GET("/orders", func(c *gin.Context) { JSON(c, http.StatusOK, gin.H{"id": 1}) })
+	if got, want := serve(other, http.MethodGet, "/orders").Body.String(), `{"id":1}`; got != want {
+		t.Errorf("other engine body = %s, want %s", got, want)
+	}
+}
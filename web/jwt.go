@@ -0,0 +1,142 @@
+package web
+
+import (
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// claimsKey is the gin context key holding the claims set by JWTAuth.
+const claimsKey = "jwt_claims"
+
+// Claims are the claims of a token accepted by JWTAuth, keyed by name
+// ("sub", "scope", ...), with numeric claims as float64.
+type Claims map[string]any
+
+// JWTOptions configures JWTAuth. Tokens must be signed with an algorithm
+// whose key is set: HMACSecret, RSAPublicKey, or both.
+type JWTOptions struct {
+	// HMACSecret verifies HS256/HS384/HS512 signatures.
+	HMACSecret []byte
+
+	// RSAPublicKey verifies RS256/RS384/RS512 signatures.
+	RSAPublicKey *rsa.PublicKey
+
+	// Issuer, if set, must equal the iss claim.
+	Issuer string
+
+	// Audience, if set, must be one of the aud claim's values.
+	Audience string
+
+	// Leeway tolerates clock skew when checking exp and nbf.
+	Leeway time.Duration
+}
+
+// JWTAuth requires a bearer token in the Authorization header, verifying
+// its signature and its exp, nbf, iss and aud claims. Requests without a
+// valid token are aborted with a 401 problem; otherwise the claims are
+// available to handlers via ClaimsFrom.
+//
+//	api := r.Group("/api", web.JWTAuth(web.JWTOptions{
+//	    HMACSecret: []byte(cfg.Auth.Secret),
+//	    Issuer:     "https://auth.example.com",
+//	}))
+func JWTAuth(opts JWTOptions) Handler {
+	parser := jwt.NewParser(jwtParserOptions(opts)...)
+	keyFunc := func(t *jwt.Token) (any, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if opts.HMACSecret != nil {
+				return opts.HMACSecret, nil
+			}
+		case *jwt.SigningMethodRSA:
+			if opts.RSAPublicKey != nil {
+				return opts.RSAPublicKey, nil
+			}
+		}
+		return nil, errors.New("unexpected signing method " + t.Method.Alg())
+	}
+
+	return func(c *gin.Context) {
+		raw, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			unauthorized(c, "missing bearer token")
+			return
+		}
+		claims := jwt.MapClaims{}
+		if _, err := parser.ParseWithClaims(raw, claims, keyFunc); err != nil {
+			unauthorized(c, "invalid token: "+tokenProblem(err))
+			return
+		}
+		c.Set(claimsKey, Claims(claims))
+		c.Next()
+	}
+}
+
+// ClaimsFrom returns the claims of the token JWTAuth accepted for this
+// request, or false if JWTAuth didn't run.
+func ClaimsFrom(c Ctx) (Claims, bool) {
+	v, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(Claims)
+	return claims, ok
+}
+
+func jwtParserOptions(opts JWTOptions) []jwt.ParserOption {
+	var methods []string
+	if opts.HMACSecret != nil {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if opts.RSAPublicKey != nil {
+		methods = append(methods, "RS256", "RS384", "RS512")
+	}
+	out := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithLeeway(opts.Leeway)}
+	if opts.Issuer != "" {
+		out = append(out, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		out = append(out, jwt.WithAudience(opts.Audience))
+	}
+	return out
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>"
+// header value.
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// tokenProblem describes why a token was rejected without echoing it.
+func tokenProblem(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "token is expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return "token is not valid yet"
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return "signature is invalid"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "unexpected issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "unexpected audience"
+	}
+	return "malformed token"
+}
+
+// unauthorized aborts with a 401 problem and a bearer challenge.
+func unauthorized(c Ctx, detail string) {
+	c.Header("WWW-Authenticate", `Bearer`)
+	Problem(c, http.StatusUnauthorized, detail)
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var jwtSecret = []byte("test-secret")
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return s
+}
+
+func jwtRouter(opts JWTOptions) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/me", JWTAuth(opts), func(c *gin.Context) {
+		claims, _ := ClaimsFrom(c)
+		c.String(http.StatusOK, "%v", claims["sub"])
+	})
+	return r
+}
+
+func getWithToken(r http.Handler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestJWTAuth(t *testing.T) {
+	now := time.Now()
+	valid := jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "https://auth.example.com",
+		"aud": "orders",
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	with := func(k string, v any) jwt.MapClaims {
+		c := jwt.MapClaims{}
+		for key, val := range valid {
+			c[key] = val
+		}
+		c[k] = v
+		return c
+	}
+
+	r := jwtRouter(JWTOptions{HMACSecret: jwtSecret, Issuer: "https://auth.example.com", Audience: "orders"})
+
+	tests := []struct {
+		name   string
+		token  string
+		detail string
+	}{
+		{"missing", "", "missing bearer token"},
+		{"expired", signToken(t, jwt.SigningMethodHS256, jwtSecret, with("exp", now.Add(-time.Minute).Unix())), "invalid token: token is expired"},
+		{"not yet valid", signToken(t, jwt.SigningMethodHS256, jwtSecret, with("nbf", now.Add(time.Hour).Unix())), "invalid token: token is not valid yet"},
+		{"bad signature", signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), valid), "invalid token: signature is invalid"},
+		{"wrong issuer", signToken(t, jwt.SigningMethodHS256, jwtSecret, with("iss", "https://evil.example.com")), "invalid token: unexpected issuer"},
+		{"wrong audience", signToken(t, jwt.SigningMethodHS256, jwtSecret, with("aud", "payments")), "invalid token: unexpected audience"},
+		{"malformed", "not-a-jwt", "invalid token: malformed token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getWithToken(r, tt.token)
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, want 401", w.Code)
+			}
+			if body := decodeProblem(t, w); body.Detail != tt.detail {
+				t.Errorf("detail = %q, want %q", body.Detail, tt.detail)
+			}
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		w := getWithToken(r, signToken(t, jwt.SigningMethodHS256, jwtSecret, valid))
+		if w.Code != http.StatusOK || w.Body.String() != "user-1" {
+			t.Errorf("GET /me = %d %q, want 200 user-1", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestJWTAuth_RSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	r := jwtRouter(JWTOptions{RSAPublicKey: &key.PublicKey})
+	claims := jwt.MapClaims{"sub": "svc", "exp": time.Now().Add(time.Hour).Unix()}
+
+	if w := getWithToken(r, signToken(t, jwt.SigningMethodRS256, key, claims)); w.Code != http.StatusOK {
+		t.Errorf("RS256 token status = %d, want 200", w.Code)
+	}
+	// HMAC tokens are rejected when only an RSA key is configured.
+	if w := getWithToken(r, signToken(t, jwt.SigningMethodHS256, []byte("secret"), claims)); w.Code != http.StatusUnauthorized {
+		t.Errorf("HS256 token status = %d, want 401", w.Code)
+	}
+}
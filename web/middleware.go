@@ -2,7 +2,9 @@ package web
 
 import (
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -27,9 +29,48 @@ func RequestID() Handler {
 	}
 }
 
-// AccessLog writes a structured access log after the request completes.
+// AccessLogOptions controls which requests AccessLog records.
+type AccessLogOptions struct {
+	// SkipMethods are HTTP methods never logged, e.g. OPTIONS preflights
+	// and HEAD health checks.
+	SkipMethods []string
+	// SkipPaths are request paths never logged, e.g. "/actuator/health".
+	SkipPaths []string
+	// SampleRate in (0, 1) logs that fraction of the remaining requests.
+	// Zero (or 1 and above) logs them all.
+	SampleRate float64
+}
+
+// AccessLog writes a structured access log after the request completes,
+// including the response size in bytes.
+//
+// The size comes from gin's ResponseWriter, which still exposes
+// http.Hijacker, http.Flusher and http.CloseNotifier, so websocket upgrades
+// and streaming (SSE) handlers work with AccessLog installed.
 func AccessLog(l *slog.Logger) Handler {
+	return AccessLogWithOptions(l, AccessLogOptions{})
+}
+
+// AccessLogWithOptions is AccessLog, skipping and sampling requests as
+// configured by opts.
+func AccessLogWithOptions(l *slog.Logger, opts AccessLogOptions) Handler {
+	skipMethods := make(map[string]bool, len(opts.SkipMethods))
+	for _, m := range opts.SkipMethods {
+		skipMethods[strings.ToUpper(m)] = true
+	}
+	skipPaths := make(map[string]bool, len(opts.SkipPaths))
+	for _, p := range opts.SkipPaths {
+		skipPaths[p] = true
+	}
+	sampled := opts.SampleRate > 0 && opts.SampleRate < 1
+
 	return func(c *gin.Context) {
+		if skipMethods[c.Request.Method] || skipPaths[c.Request.URL.Path] ||
+			(sampled && rand.Float64() >= opts.SampleRate) {
+			c.Next()
+			return
+		}
+
 		start := time.Now()
 		c.Next()
 		dur := time.Since(start)
@@ -37,6 +78,7 @@ func AccessLog(l *slog.Logger) Handler {
 			"method", c.Request.Method,
 			"path", c.FullPath(),
 			"status", c.Writer.Status(),
+			"bytes", max(c.Writer.Size(), 0),
 			"duration_ms", dur.Milliseconds(),
 			"ip", c.ClientIP(),
 			"req_id", c.GetString("request_id"),
@@ -50,14 +92,7 @@ func RecoveryProblem(l *slog.Logger) Handler {
 		defer func() {
 			if rec := recover(); rec != nil {
 				l.Error("panic", "error", rec)
-				c.Header("Content-Type", "application/problem+json")
-				c.JSON(http.StatusInternalServerError, map[string]any{
-					"type":   "about:blank",
-					"title":  "Internal Server Error",
-					"status": http.StatusInternalServerError,
-					"detail": "unexpected server error",
-				})
-				c.Abort()
+				Problem(c, http.StatusInternalServerError, "unexpected server error")
 			}
 		}()
 		c.Next()
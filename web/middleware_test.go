@@ -0,0 +1,195 @@
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// syncBuffer is a bytes.Buffer safe for the server and test goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// accessLogged returns the first http_access record written to buf.
+func accessLogged(t *testing.T, buf *syncBuffer) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, line := range strings.Split(buf.String(), "\n") {
+			var rec map[string]any
+			if json.Unmarshal([]byte(line), &rec) == nil && rec["msg"] == "http_access" {
+				return rec
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("no http_access record logged")
+	return nil
+}
+
+func newAccessLogEngine(buf *syncBuffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(AccessLog(slog.New(slog.NewJSONHandler(buf, nil))))
+	return r
+}
+
+func TestAccessLog_ResponseSize(t *testing.T) {
+	buf := &syncBuffer{}
+	r := newAccessLogEngine(buf)
+	r.GET("/orders", func(c *gin.Context) { c.String(http.StatusOK, "hello") })
+
+	serve(r, http.MethodGet, "/orders")
+
+	if got := accessLogged(t, buf)["bytes"]; got != float64(5) {
+		t.Errorf("bytes = %v, want 5", got)
+	}
+}
+
+func TestAccessLog_StreamingFlush(t *testing.T) {
+	buf := &syncBuffer{}
+	r := newAccessLogEngine(buf)
+
+	firstRead := make(chan struct{})
+	r.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		io.WriteString(c.Writer, "data: first\n\n")
+		c.Writer.(http.Flusher).Flush()
+
+		// The client can only read the first event if it was flushed.
+		select {
+		case <-firstRead:
+		case <-time.After(2 * time.Second):
+		}
+		io.WriteString(c.Writer, "data: second\n\n")
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	if err != nil || line != "data: first\n" {
+		t.Fatalf("first line = %q, %v; want flushed event", line, err)
+	}
+	close(firstRead)
+
+	rest, _ := io.ReadAll(reader)
+	if !strings.Contains(string(rest), "data: second") {
+		t.Errorf("rest = %q, want second event", rest)
+	}
+	if got := accessLogged(t, buf)["bytes"]; got != float64(len("data: first\n\ndata: second\n\n")) {
+		t.Errorf("bytes = %v, want size of both events", got)
+	}
+}
+
+func TestAccessLog_Hijack(t *testing.T) {
+	buf := &syncBuffer{}
+	r := newAccessLogEngine(buf)
+	r.GET("/ws", func(c *gin.Context) {
+		conn, rw, err := c.Writer.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error = %v", err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\nhello")
+		rw.Flush()
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial error = %v", err)
+	}
+	defer conn.Close()
+	io.WriteString(conn, "GET /ws HTTP/1.1\r\nHost: test\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	got, _ := io.ReadAll(conn)
+	if !strings.HasPrefix(string(got), "HTTP/1.1 101") || !strings.HasSuffix(string(got), "hello") {
+		t.Errorf("response = %q, want upgraded connection", got)
+	}
+	if rec := accessLogged(t, buf); rec["path"] != "/ws" {
+		t.Errorf("logged path = %v, want /ws", rec["path"])
+	}
+}
+
+func TestAccessLogWithOptions_Skips(t *testing.T) {
+	buf := &syncBuffer{}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(AccessLogWithOptions(slog.New(slog.NewJSONHandler(buf, nil)), AccessLogOptions{
+		SkipMethods: []string{"options", http.MethodHead},
+		SkipPaths:   []string{"/health"},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/orders", ok)
+	r.OPTIONS("/orders", ok)
+	r.HEAD("/orders", ok)
+	r.GET("/health", ok)
+
+	serve(r, http.MethodOptions, "/orders")
+	serve(r, http.MethodHead, "/orders")
+	serve(r, http.MethodGet, "/health")
+	serve(r, http.MethodGet, "/orders")
+
+	logged := strings.Count(buf.String(), `"msg":"http_access"`)
+	if logged != 1 {
+		t.Fatalf("logged %d requests, want only GET /orders:\n%s", logged, buf.String())
+	}
+	if rec := accessLogged(t, buf); rec["method"] != http.MethodGet || rec["path"] != "/orders" {
+		t.Errorf("logged %v %v, want GET /orders", rec["method"], rec["path"])
+	}
+}
+
+func TestAccessLogWithOptions_SampleRate(t *testing.T) {
+	buf := &syncBuffer{}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(AccessLogWithOptions(slog.New(slog.NewJSONHandler(buf, nil)), AccessLogOptions{SampleRate: 0.5}))
+	r.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	const requests = 400
+	for range requests {
+		serve(r, http.MethodGet, "/orders")
+	}
+
+	logged := strings.Count(buf.String(), `"msg":"http_access"`)
+	if logged < requests/4 || logged > requests*3/4 {
+		t.Errorf("logged %d of %d requests, want about half", logged, requests)
+	}
+}
@@ -2,9 +2,12 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -32,34 +35,115 @@ func Module(opts ...Option) core.Module {
 }
 
 type webModule struct {
-	opts   Options
-	server *http.Server
+	opts     Options
+	server   *http.Server
+	listener net.Listener
+	serving  atomic.Bool // listener bound and not draining
+}
+
+// ListeningFunc reports the address the server accepts connections on, or
+// false before Start has bound it and once it's draining. The web module
+// puts one in the container, for readiness checks.
+type ListeningFunc func() (net.Addr, bool)
+
+func (m *webModule) listening() (net.Addr, bool) {
+	if !m.serving.Load() {
+		return nil, false
+	}
+	return m.listener.Addr(), true
 }
 
 func (m *webModule) Name() string        { return Name }
 func (m *webModule) DependsOn() []string { return nil }
 
+// Requires declares what Configure gets from the container, so a missing
+// entry fails the app's Run instead of panicking.
+func (m *webModule) Requires() []any {
+	return []any{core.TypeKey[config.Root]{}, core.TypeKey[*slog.Logger]{}}
+}
+
 func (m *webModule) Configure(c core.Container) error {
 	cfg := core.Get[config.Root](c)
 	l := core.Get[*slog.Logger](c)
 
-	gin.SetMode(gin.ReleaseMode)
+	mode, err := ginMode(cfg.Server.Mode)
+	if err != nil {
+		return err
+	}
+	policies, err := corsFrom(m.opts.CORS, cfg.Server.CORS)
+	if err != nil {
+		return err
+	}
+	gin.SetMode(mode)
 	r := gin.New()
+	if m.opts.JSONCodec != nil {
+		r.Use(useJSONCodec(m.opts.JSONCodec))
+	}
 
-	// Middlewares: request ID, recovery, access log
+	// Canceled when the server starts shutting down, for ShutdownContext
+	draining, drain := context.WithCancel(context.Background())
+	r.Use(shutdownSignal(draining))
+
+	// Middlewares: problem types, request ID, recovery, access log
+	if m.opts.ProblemTypeBase != "" {
+		r.Use(ProblemTypeBase(m.opts.ProblemTypeBase))
+	}
 	r.Use(RequestID())
 	r.Use(RecoveryProblem(l))
-	r.Use(AccessLog(l))
+	r.Use(AccessLogWithOptions(l, m.opts.AccessLog))
+	if m.opts.SlowLogCapacity > 0 {
+		window := m.opts.SlowLogWindow
+		if window <= 0 {
+			window = DefaultSlowLogWindow
+		}
+		slow, entries := SlowLogWithin(m.opts.SlowLogCapacity, window)
+		r.Use(slow)
+		core.Put(c, entries)
+	}
+
+	// CORS policies and request timeouts, following config reloads if
+	// there's a Manager
+	var (
+		cors     atomic.Pointer[corsPolicies]
+		timeouts atomic.Pointer[routeTimeouts]
+	)
+	cors.Store(policies)
+	timeouts.Store(timeoutsFrom(cfg.Server))
+	if v, ok := c.Get(core.TypeKey[*config.Manager]{}); ok {
+		config.OnChange(v.(*config.Manager), func(_, cfg config.Root) {
+			if policies, err := corsFrom(m.opts.CORS, cfg.Server.CORS); err != nil {
+				l.Error("cors policies not reloaded", "error", err)
+			} else {
+				cors.Store(policies)
+			}
+			timeouts.Store(timeoutsFrom(cfg.Server))
+		})
+	}
+	r.Use(routeCORS(&cors))
+	r.Use(routeTimeout(&timeouts))
 
 	// Allow other modules/app to register routes
 	var root Router = r
 	if cfg.Actuator.BasePath != "" && cfg.Actuator.BasePath != "/" {
 		// basePath is handled by route composers in your app if desired.
 	}
+	RegisterRoutes(root, m.opts.RouteTable...)
 	for _, reg := range m.opts.Routes {
 		reg(root)
 	}
 
+	// Unmatched routes get the same error shape as handlers
+	notFound, notAllowed := m.opts.NotFound, m.opts.MethodNotAllowed
+	if notFound == nil {
+		notFound = NotFoundProblem()
+	}
+	if notAllowed == nil {
+		notAllowed = MethodNotAllowedProblem()
+	}
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(notFound)
+	r.NoMethod(notAllowed)
+
 	// HTTP server
 	srv := &http.Server{
 		Addr:         cfg.Server.Addr,
@@ -68,22 +152,72 @@ func (m *webModule) Configure(c core.Container) error {
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
+	srv.RegisterOnShutdown(drain)
+	if cfg.Server.TLS.Enabled {
+		certs, err := newCertReloader(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		if err != nil {
+			return err
+		}
+		srv.TLSConfig = &tls.Config{GetCertificate: certs.GetCertificate}
+	}
 
 	core.Put[*gin.Engine](c, r)
 	core.Put[*http.Server](c, srv)
+	core.Put(c, ListeningFunc(m.listening))
 	m.server = srv
 	return nil
 }
 
+// ginMode maps the configured server mode to a gin mode, defaulting to release.
+func ginMode(mode string) (string, error) {
+	switch mode {
+	case "", gin.ReleaseMode:
+		return gin.ReleaseMode, nil
+	case gin.DebugMode, gin.TestMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("web: unknown server mode %q (want debug, release or test)", mode)
+	}
+}
+
+// Start binds the listener before returning, so an address in use fails
+// the app's Run, then serves on it in the background. The module reports
+// itself listening (see ListeningFunc) from then until it drains.
 func (m *webModule) Start(ctx context.Context, c core.Container) error {
-	cfg := core.Get[config.Root](c)
 	l := core.Get[*slog.Logger](c)
-	go func() {
-		l.Info("http server starting", "addr", cfg.Server.Addr)
-		if err := m.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			l.Error("http server error", "error", err)
+	ln, err := net.Listen("tcp", m.server.Addr)
+	if err != nil {
+		return fmt.Errorf("http listen: %w", err)
+	}
+	m.listener = ln
+	m.serving.Store(true)
+	l.Info("http server starting", "addr", ln.Addr().String(), "tls", m.server.TLSConfig != nil)
+	core.Get[*core.Group](c).Go(func(context.Context) error {
+		defer m.serving.Store(false)
+		serve := m.server.Serve
+		if m.server.TLSConfig != nil {
+			// Certificates come from TLSConfig.GetCertificate.
+			serve = func(ln net.Listener) error { return m.server.ServeTLS(ln, "", "") }
+		}
+		if err := serve(ln); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("http serve: %w", err)
 		}
-	}()
+		return nil
+	})
+	return nil
+}
+
+// PreStop stops the server from accepting new connections and waits for
+// in-flight requests to finish, while modules it serves are still running.
+func (m *webModule) PreStop(ctx context.Context, c core.Container) error {
+	l := core.Get[*slog.Logger](c)
+	l.Info("http server draining")
+	m.serving.Store(false)
+	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := m.server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("http drain: %w", err)
+	}
 	return nil
 }
 
@@ -0,0 +1,232 @@
+package web
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/core"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func testRoot() config.Root {
+	return config.Root{
+		App:    config.AppInfo{Name: "test", Version: "0.0.0"},
+		Server: config.ServerConfig{Addr: "127.0.0.1:0"},
+	}
+}
+
+// dependentModule depends on the web module and records whether the server
+// was still accepting connections when it was stopped.
+type dependentModule struct {
+	web       *webModule
+	started   chan struct{}
+	accepting chan bool
+}
+
+func (m *dependentModule) Name() string                     { return "dependent" }
+func (m *dependentModule) DependsOn() []string              { return []string{Name} }
+func (m *dependentModule) Configure(c core.Container) error { return nil }
+func (m *dependentModule) Start(context.Context, core.Container) error {
+	close(m.started)
+	return nil
+}
+
+func (m *dependentModule) Stop(ctx context.Context, c core.Container) error {
+	conn, err := net.DialTimeout("tcp", m.web.listener.Addr().String(), time.Second)
+	if err == nil {
+		conn.Close()
+	}
+	m.accepting <- err == nil
+	return nil
+}
+
+func TestModule_StopsAcceptingBeforeDependentsStop(t *testing.T) {
+	web := Module().(*webModule)
+	dep := &dependentModule{
+		web:       web,
+		started:   make(chan struct{}),
+		accepting: make(chan bool, 1),
+	}
+
+	app := core.NewApp(testLogger(), web, dep)
+	core.Put(app.Container, testRoot())
+	core.Put(app.Container, testLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() { errCh <- app.Run(ctx) }()
+
+	select {
+	case <-dep.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("app did not start in time")
+	}
+
+	// Sanity check: the server accepts while running.
+	conn, err := net.DialTimeout("tcp", web.listener.Addr().String(), time.Second)
+	if err != nil {
+		t.Fatalf("server not accepting while running: %v", err)
+	}
+	conn.Close()
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if <-dep.accepting {
+		t.Error("web server still accepted connections when its dependent was stopped")
+	}
+}
+
+func TestModule_MissingDependencyIsAnError(t *testing.T) {
+	// No logger: web's Configure would panic on core.Get without Requires.
+	app := core.New(testLogger(),
+		core.WithValue(core.TypeKey[config.Root]{}, testRoot()),
+		core.WithModules(Module(), &dependentModule{started: make(chan struct{})}),
+	)
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("Run() panicked: %v", r)
+			}
+		}()
+		err = app.Run(context.Background())
+	}()
+	if err == nil || !strings.Contains(err.Error(), "module web requires *slog.Logger") {
+		t.Errorf("Run() error = %v, want the missing logger reported", err)
+	}
+}
+
+func TestModule_ConfigureMode(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"", gin.ReleaseMode},
+		{"release", gin.ReleaseMode},
+		{"debug", gin.DebugMode},
+		{"test", gin.TestMode},
+	}
+
+	for _, tt := range tests {
+		t.Run("mode="+tt.mode, func(t *testing.T) {
+			root := testRoot()
+			root.Server.Mode = tt.mode
+			c := core.NewContainer()
+			core.Put(c, root)
+			core.Put(c, testLogger())
+
+			if err := Module().Configure(c); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			if got := gin.Mode(); got != tt.want {
+				t.Errorf("gin.Mode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_ConfigureInvalidMode(t *testing.T) {
+	root := testRoot()
+	root.Server.Mode = "production"
+	c := core.NewContainer()
+	core.Put(c, root)
+	core.Put(c, testLogger())
+
+	if err := Module().Configure(c); err == nil {
+		t.Fatal("Configure() with invalid mode: expected error")
+	}
+}
+
+func TestModule_RouteTable(t *testing.T) {
+	c := core.NewContainer()
+	core.Put(c, testRoot())
+	core.Put(c, testLogger())
+
+	tagged := func(c *gin.Context) {
+		c.Header("X-Middleware", "ran")
+		c.Next()
+	}
+	mod := Module(WithRouteTable(
+		Route{Method: http.MethodGet, Path: "/orders", Handler: func(c *gin.Context) { c.String(http.StatusOK, "list") }},
+		Route{Method: http.MethodPost, Path: "/orders", Handler: func(c *gin.Context) { c.String(http.StatusCreated, "create") },
+			Middlewares: []Handler{tagged}},
+	))
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := Engine(c)
+
+	if w := serve(engine, http.MethodGet, "/orders"); w.Code != http.StatusOK || w.Body.String() != "list" {
+		t.Errorf("GET /orders = %d %q, want 200 list", w.Code, w.Body.String())
+	}
+	w := serve(engine, http.MethodPost, "/orders")
+	if w.Code != http.StatusCreated || w.Header().Get("X-Middleware") != "ran" {
+		t.Errorf("POST /orders = %d (X-Middleware %q), want 201 with route middleware", w.Code, w.Header().Get("X-Middleware"))
+	}
+}
+
+func TestModule_StartBindsListener(t *testing.T) {
+	newStarted := func(root config.Root) (*webModule, core.Container, *core.Group) {
+		c := core.NewContainer()
+		core.Put(c, root)
+		core.Put(c, testLogger())
+		group := core.NewGroup(context.Background())
+		core.Put(c, group)
+		mod := Module().(*webModule)
+		if err := mod.Configure(c); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		return mod, c, group
+	}
+
+	mod, c, group := newStarted(testRoot())
+	defer group.Wait()
+	listening := core.Get[ListeningFunc](c)
+	if _, ok := listening(); ok {
+		t.Error("ListeningFunc() = true before Start")
+	}
+	if err := mod.Start(context.Background(), c); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	addr, ok := listening()
+	if !ok || addr.String() != mod.listener.Addr().String() {
+		t.Fatalf("ListeningFunc() = %v, %v after Start, want %v, true", addr, ok, mod.listener.Addr())
+	}
+
+	// A second server on the same address fails in Start, not later.
+	root := testRoot()
+	root.Server.Addr = addr.String()
+	other, otherC, _ := newStarted(root)
+	if err := other.Start(context.Background(), otherC); err == nil || !strings.Contains(err.Error(), "http listen") {
+		t.Errorf("Start() on a bound address error = %v, want http listen error", err)
+	}
+	if _, ok := core.Get[ListeningFunc](otherC)(); ok {
+		t.Error("ListeningFunc() = true after a failed Start")
+	}
+
+	if err := mod.PreStop(context.Background(), c); err != nil {
+		t.Fatalf("PreStop() error = %v", err)
+	}
+	if _, ok := listening(); ok {
+		t.Error("ListeningFunc() = true while draining")
+	}
+}
@@ -0,0 +1,76 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// problemTypeBaseKey is the gin context key holding the base URI for
+// problem types, set by ProblemTypeBase.
+const problemTypeBaseKey = "problem_type_base"
+
+// Problem aborts the request with an RFC7807 "problem+json" response.
+//
+// The title is the standard status text, and detail describes this
+// occurrence of the problem. The type is "about:blank" unless
+// ProblemTypeBase is installed, in which case it's the base URI joined with
+// a slug of the status text, e.g. https://docs.example.com/errors/internal-server-error.
+func Problem(c Ctx, status int, detail string) {
+	writeProblem(c, status, detail, nil)
+}
+
+// writeProblem writes a problem+json response with optional extension
+// members alongside the standard ones.
+func writeProblem(c Ctx, status int, detail string, extensions gin.H) {
+	body := gin.H{
+		"type":   problemType(c, status),
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": detail,
+	}
+	for k, v := range extensions {
+		body[k] = v
+	}
+	c.Header("Content-Type", "application/problem+json")
+	c.Abort()
+	JSON(c, status, body)
+}
+
+// NotFoundProblem responds to unmatched routes with a 404 problem.
+func NotFoundProblem() Handler {
+	return func(c *gin.Context) {
+		Problem(c, http.StatusNotFound, "no route for "+c.Request.Method+" "+c.Request.URL.Path)
+	}
+}
+
+// MethodNotAllowedProblem responds to a request whose method isn't routed
+// for its path with a 405 problem.
+func MethodNotAllowedProblem() Handler {
+	return func(c *gin.Context) {
+		Problem(c, http.StatusMethodNotAllowed, "method "+c.Request.Method+" is not allowed for "+c.Request.URL.Path)
+	}
+}
+
+// ProblemTypeBase makes problem responses use dereferenceable type URIs
+// under base (e.g. "https://docs.example.com/errors") instead of
+// "about:blank", pointing clients at documentation for each error class.
+// Install it before any middleware that writes problems.
+func ProblemTypeBase(base string) Handler {
+	base = strings.TrimSuffix(base, "/")
+	return func(c *gin.Context) {
+		c.Set(problemTypeBaseKey, base)
+		c.Next()
+	}
+}
+
+// problemType returns the type URI for status.
+func problemType(c Ctx, status int) string {
+	base := c.GetString(problemTypeBaseKey)
+	if base == "" {
+		return "about:blank"
+	}
+	slug := strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "-"))
+	return base + "/" + slug
+}
@@ -0,0 +1,109 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/core"
+)
+
+func TestProblem_TypeURI(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, "about:blank"},
+		{"configured base", []Option{WithProblemTypeBase("https://docs.example.com/errors/")}, "https://docs.example.com/errors/internal-server-error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := core.NewContainer()
+			core.Put(c, testRoot())
+			core.Put(c, testLogger())
+
+			opts := append(tt.opts, WithRoutes(func(r Router) {
+				r.GET("/panic", func(*gin.Context) { panic("boom") })
+			}))
+			if err := Module(opts...).Configure(c); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+
+			w := serve(Engine(c), http.MethodGet, "/panic")
+			var body struct {
+				Type   string `json:"type"`
+				Status int    `json:"status"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid problem body: %v", err)
+			}
+			if body.Status != http.StatusInternalServerError || body.Type != tt.want {
+				t.Errorf("problem = %+v, want type %q", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_UnmatchedRouteProblems(t *testing.T) {
+	c := core.NewContainer()
+	core.Put(c, testRoot())
+	core.Put(c, testLogger())
+
+	mod := Module(WithRoutes(func(r Router) {
+		r.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+	}))
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := Engine(c)
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/missing", http.StatusNotFound},
+		{http.MethodDelete, "/orders", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		w := serve(engine, tt.method, tt.path)
+		if w.Code != tt.want {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, w.Code, tt.want)
+			continue
+		}
+		if body := decodeProblem(t, w); body.Status != tt.want || body.Detail == "" {
+			t.Errorf("%s %s problem = %+v", tt.method, tt.path, body)
+		}
+		if id := w.Header().Get("X-Request-ID"); id == "" {
+			t.Errorf("%s %s missing X-Request-ID from global middleware", tt.method, tt.path)
+		}
+	}
+}
+
+func TestModule_CustomNotFound(t *testing.T) {
+	c := core.NewContainer()
+	core.Put(c, testRoot())
+	core.Put(c, testLogger())
+
+	mod := Module(
+		WithNotFound(func(c *gin.Context) { c.String(http.StatusNotFound, "nothing here") }),
+		WithMethodNotAllowed(func(c *gin.Context) { c.String(http.StatusMethodNotAllowed, "try GET") }),
+		WithRoutes(func(r Router) {
+			r.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+		}),
+	)
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := Engine(c)
+
+	if w := serve(engine, http.MethodGet, "/missing"); w.Code != http.StatusNotFound || w.Body.String() != "nothing here" {
+		t.Errorf("GET /missing = %d %q", w.Code, w.Body.String())
+	}
+	if w := serve(engine, http.MethodPost, "/orders"); w.Code != http.StatusMethodNotAllowed || w.Body.String() != "try GET" {
+		t.Errorf("POST /orders = %d %q", w.Code, w.Body.String())
+	}
+}
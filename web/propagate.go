@@ -0,0 +1,37 @@
+package web
+
+import "net/http"
+
+// PropagateRequestID wraps rt so outbound requests carry the request ID of
+// the inbound request c (as set by RequestID) in X-Request-ID, linking logs
+// across services. A nil rt uses http.DefaultTransport. Requests that
+// already set X-Request-ID, or made outside RequestID, are sent unchanged.
+//
+// Create the client per inbound request, inside the handler:
+//
+//	func getInventory(c web.Ctx) {
+//	    client := &http.Client{Transport: web.PropagateRequestID(nil, c)}
+//	    resp, err := client.Get("http://inventory/items")
+//	    ...
+//	}
+func PropagateRequestID(rt http.RoundTripper, c Ctx) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &requestIDTransport{next: rt, id: c.GetString("request_id")}
+}
+
+type requestIDTransport struct {
+	next http.RoundTripper
+	id   string
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.id == "" || req.Header.Get("X-Request-ID") != "" {
+		return t.next.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Request-ID", t.id)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPropagateRequestID(t *testing.T) {
+	var downstreamID string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamID = r.Header.Get("X-Request-ID")
+	}))
+	defer downstream.Close()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/orders", func(c *gin.Context) {
+		client := &http.Client{Transport: PropagateRequestID(nil, c)}
+		resp, err := client.Get(downstream.URL)
+		if err != nil {
+			t.Errorf("outbound request error = %v", err)
+			return
+		}
+		resp.Body.Close()
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	r.ServeHTTP(w, req)
+
+	if downstreamID != "req-123" {
+		t.Errorf("downstream X-Request-ID = %q, want %q", downstreamID, "req-123")
+	}
+}
+
+func TestPropagateRequestID_KeepsExplicitHeader(t *testing.T) {
+	var downstreamID string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamID = r.Header.Get("X-Request-ID")
+	}))
+	defer downstream.Close()
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("request_id", "req-123")
+
+	req, _ := http.NewRequest(http.MethodGet, downstream.URL, nil)
+	req.Header.Set("X-Request-ID", "explicit")
+	resp, err := (&http.Client{Transport: PropagateRequestID(nil, c)}).Do(req)
+	if err != nil {
+		t.Fatalf("outbound request error = %v", err)
+	}
+	resp.Body.Close()
+
+	if downstreamID != "explicit" {
+		t.Errorf("downstream X-Request-ID = %q, want %q", downstreamID, "explicit")
+	}
+}
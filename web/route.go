@@ -0,0 +1,26 @@
+package web
+
+// Route declares an HTTP route as data.
+//
+// Example:
+//
+//	web.Module(web.WithRouteTable(
+//	    web.Route{Method: http.MethodGet, Path: "/orders", Handler: listOrders},
+//	    web.Route{Method: http.MethodPost, Path: "/orders", Handler: createOrder,
+//	        Middlewares: []web.Handler{requireAuth}},
+//	))
+type Route struct {
+	Method  string
+	Path    string
+	Handler Handler
+	// Middlewares run before Handler, in order, for this route only.
+	Middlewares []Handler
+}
+
+// RegisterRoutes registers each route on r.
+func RegisterRoutes(r Router, routes ...Route) {
+	for _, rt := range routes {
+		handlers := append(append([]Handler(nil), rt.Middlewares...), rt.Handler)
+		r.Handle(rt.Method, rt.Path, handlers...)
+	}
+}
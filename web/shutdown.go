@@ -0,0 +1,53 @@
+package web
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// shutdownKey is the gin context key holding the context that is canceled
+// when the server begins draining, set by the web module.
+const shutdownKey = "shutdown_ctx"
+
+// ShutdownContext returns a context that is done when the request ends or
+// the server begins shutting down, whichever comes first.
+//
+// Server shutdown waits for in-flight requests, so long-lived handlers (SSE
+// streams, long-polls) must return once draining starts or they hold it up
+// until its timeout. Select on this context instead of the request's:
+//
+//	r.GET("/events", func(c web.Ctx) {
+//	    ctx := web.ShutdownContext(c)
+//	    for {
+//	        select {
+//	        case <-ctx.Done():
+//	            return // end the stream cleanly
+//	        case ev := <-events:
+//	            c.SSEvent("message", ev)
+//	            c.Writer.Flush()
+//	        }
+//	    }
+//	})
+//
+// Outside the web module's engine it's the request context.
+func ShutdownContext(c Ctx) context.Context {
+	reqCtx := c.Request.Context()
+	v, ok := c.Get(shutdownKey)
+	if !ok {
+		return reqCtx
+	}
+	ctx, cancel := context.WithCancel(reqCtx)
+	stop := context.AfterFunc(v.(context.Context), cancel)
+	// Unregister from the shutdown context once the request is done.
+	context.AfterFunc(ctx, func() { stop() })
+	return ctx
+}
+
+// shutdownSignal exposes ctx to handlers via ShutdownContext.
+func shutdownSignal(ctx context.Context) Handler {
+	return func(c *gin.Context) {
+		c.Set(shutdownKey, ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/core"
+)
+
+func TestShutdownContext_EndsStreamOnDrain(t *testing.T) {
+	c := core.NewContainer()
+	core.Put(c, testRoot())
+	core.Put(c, testLogger())
+	group := core.NewGroup(context.Background())
+	core.Put(c, group)
+
+	mod := Module(WithRoutes(func(r Router) {
+		r.GET("/events", func(c *gin.Context) {
+			ctx := ShutdownContext(c)
+			c.Header("Content-Type", "text/event-stream")
+			io.WriteString(c.Writer, "data: hello\n\n")
+			c.Writer.Flush()
+			<-ctx.Done()
+			io.WriteString(c.Writer, "data: bye\n\n")
+		})
+	})).(*webModule)
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := mod.Start(context.Background(), c); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer group.Wait()
+
+	resp, err := http.Get("http://" + mod.listener.Addr().String() + "/events")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	if line, err := reader.ReadString('\n'); err != nil || line != "data: hello\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := mod.PreStop(ctx, c); err != nil {
+		t.Fatalf("PreStop() error = %v, want stream to end when draining starts", err)
+	}
+
+	rest, _ := io.ReadAll(reader)
+	if !strings.Contains(string(rest), "data: bye") {
+		t.Errorf("rest = %q, want final event", rest)
+	}
+}
+
+func TestShutdownContext_OutsideModule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		if ShutdownContext(c) != c.Request.Context() {
+			t.Error("ShutdownContext() is not the request context outside the module")
+		}
+	})
+	serve(r, http.MethodGet, "/")
+}
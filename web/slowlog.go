@@ -0,0 +1,100 @@
+package web
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultSlowLogWindow is how long SlowLog considers a request recent.
+const DefaultSlowLogWindow = 15 * time.Minute
+
+// SlowEntry describes one request retained by SlowLog.
+type SlowEntry struct {
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Status   int           `json:"status"`
+	Duration time.Duration `json:"duration"`
+	Time     time.Time     `json:"time"`
+}
+
+// SlowLogFunc returns the retained slow requests, slowest first.
+type SlowLogFunc func() []SlowEntry
+
+// SlowLog keeps in memory the capacity slowest requests of the last
+// DefaultSlowLogWindow. See SlowLogWithin.
+func SlowLog(capacity int) (Handler, SlowLogFunc) {
+	return SlowLogWithin(capacity, DefaultSlowLogWindow)
+}
+
+// SlowLogWithin keeps in memory the capacity slowest requests that started
+// within the last window. Older requests age out, so a spike at startup
+// doesn't hide the slow requests that follow it.
+//
+// It times requests the same way AccessLog does and returns the middleware
+// together with a function that snapshots the retained entries, slowest
+// first. It's a cheap always-on aid for performance triage.
+func SlowLogWithin(capacity int, window time.Duration) (Handler, SlowLogFunc) {
+	return slowLog(capacity, window, time.Now)
+}
+
+func slowLog(capacity int, window time.Duration, now func() time.Time) (Handler, SlowLogFunc) {
+	var (
+		mu      sync.Mutex
+		entries []SlowEntry // sorted by Duration, descending
+	)
+
+	// expire drops entries older than window; mu must be held.
+	expire := func(at time.Time) {
+		kept := entries[:0]
+		for _, e := range entries {
+			if at.Sub(e.Time) <= window {
+				kept = append(kept, e)
+			}
+		}
+		clear(entries[len(kept):])
+		entries = kept
+	}
+
+	handler := func(c *gin.Context) {
+		start := now()
+		c.Next()
+		end := now()
+		dur := end.Sub(start)
+		if capacity <= 0 {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		expire(end)
+		if len(entries) == capacity && dur <= entries[len(entries)-1].Duration {
+			return
+		}
+		e := SlowEntry{
+			Method:   c.Request.Method,
+			Path:     c.FullPath(),
+			Status:   c.Writer.Status(),
+			Duration: dur,
+			Time:     start,
+		}
+		i := sort.Search(len(entries), func(i int) bool { return entries[i].Duration < dur })
+		entries = append(entries, SlowEntry{})
+		copy(entries[i+1:], entries[i:])
+		entries[i] = e
+		if len(entries) > capacity {
+			entries = entries[:capacity]
+		}
+	}
+
+	snapshot := func() []SlowEntry {
+		mu.Lock()
+		defer mu.Unlock()
+		expire(now())
+		return append([]SlowEntry(nil), entries...)
+	}
+
+	return handler, snapshot
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSlowLog_RetainsSlowest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, entries := SlowLog(2)
+
+	r := gin.New()
+	r.Use(handler)
+	for _, route := range []struct {
+		path  string
+		delay time.Duration
+	}{
+		{"/fast", 0},
+		{"/medium", 20 * time.Millisecond},
+		{"/slow", 40 * time.Millisecond},
+		{"/quick", time.Millisecond},
+	} {
+		delay := route.delay
+		r.GET(route.path, func(c *gin.Context) {
+			time.Sleep(delay)
+			c.Status(http.StatusOK)
+		})
+	}
+
+	for _, path := range []string{"/medium", "/fast", "/slow", "/quick"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	got := entries()
+	if len(got) != 2 {
+		t.Fatalf("entries() returned %d entries, want 2: %+v", len(got), got)
+	}
+	if got[0].Path != "/slow" || got[1].Path != "/medium" {
+		t.Errorf("entries() paths = [%s %s], want [/slow /medium]", got[0].Path, got[1].Path)
+	}
+	if got[0].Duration < got[1].Duration {
+		t.Errorf("entries() not sorted slowest first: %v < %v", got[0].Duration, got[1].Duration)
+	}
+	if got[0].Status != http.StatusOK || got[0].Method != http.MethodGet {
+		t.Errorf("entries()[0] = %+v, want GET with status 200", got[0])
+	}
+}
+
+func TestSlowLog_ZeroCapacity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, entries := SlowLog(0)
+
+	r := gin.New()
+	r.Use(handler)
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := entries(); len(got) != 0 {
+		t.Errorf("entries() = %+v, want none", got)
+	}
+}
+
+func TestSlowLog_AgesOutOldRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	handler, entries := slowLog(1, time.Minute, func() time.Time { return clock })
+
+	r := gin.New()
+	r.Use(handler)
+	delays := map[string]time.Duration{"/spike": 10 * time.Second, "/slow": 2 * time.Second}
+	for path, delay := range delays {
+		r.GET(path, func(c *gin.Context) {
+			clock = clock.Add(delay)
+			c.Status(http.StatusOK)
+		})
+	}
+	serve := func(path string) {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	serve("/spike")
+	serve("/slow")
+	if got := entries(); len(got) != 1 || got[0].Path != "/spike" {
+		t.Fatalf("entries() = %+v, want only the spike while it's recent", got)
+	}
+
+	clock = clock.Add(2 * time.Minute)
+	if got := entries(); len(got) != 0 {
+		t.Errorf("entries() = %+v, want none once the window has passed", got)
+	}
+	serve("/slow")
+	if got := entries(); len(got) != 1 || got[0].Path != "/slow" {
+		t.Errorf("entries() = %+v, want the new slow request after the spike aged out", got)
+	}
+}
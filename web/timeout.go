@@ -0,0 +1,75 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/config"
+)
+
+// Timeout bounds the rest of the handler chain to d, by setting a deadline
+// on the request context. If the deadline passes before a response is
+// written, the client gets a 503 problem.
+//
+// Handlers must honor the context (pass it to queries and outbound calls,
+// or select on Done) for the timeout to cut them short; Timeout can't stop
+// a handler that ignores it. A zero or negative d disables the timeout.
+//
+// The web module applies per-route timeouts from server.requestTimeout and
+// server.routeTimeouts itself; use Timeout for routes that should have a
+// fixed one.
+func Timeout(d time.Duration) Handler {
+	return func(c *gin.Context) { withTimeout(c, d) }
+}
+
+// routeTimeouts holds the current server-wide and per-route timeouts.
+type routeTimeouts struct {
+	def    time.Duration
+	routes map[string]time.Duration
+}
+
+// lookup returns the timeout for the route of method and pattern.
+func (t *routeTimeouts) lookup(method, pattern string) time.Duration {
+	if d, ok := t.routes[method+" "+pattern]; ok {
+		return d
+	}
+	if d, ok := t.routes[pattern]; ok {
+		return d
+	}
+	return t.def
+}
+
+// routeTimeout applies the timeouts in cur to each request, by the pattern
+// of the route it matched. cur is swapped when the config reloads.
+func routeTimeout(cur *atomic.Pointer[routeTimeouts]) Handler {
+	return func(c *gin.Context) {
+		withTimeout(c, cur.Load().lookup(c.Request.Method, c.FullPath()))
+	}
+}
+
+// timeoutsFrom extracts the timeouts from the server config.
+func timeoutsFrom(cfg config.ServerConfig) *routeTimeouts {
+	return &routeTimeouts{def: cfg.RequestTimeout, routes: cfg.RouteTimeouts}
+}
+
+func withTimeout(c *gin.Context, d time.Duration) {
+	if d <= 0 {
+		c.Next()
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Next()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+		c.Abort()
+		Problem(c, http.StatusServiceUnavailable, "request timed out after "+d.String())
+	}
+}
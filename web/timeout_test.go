@@ -0,0 +1,119 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/skekre98/genever/config"
+	"github.com/skekre98/genever/config/source"
+	"github.com/skekre98/genever/core"
+)
+
+// slowHandler responds after d unless the request context ends first.
+func slowHandler(d time.Duration) Handler {
+	return func(c *gin.Context) {
+		select {
+		case <-time.After(d):
+			c.Status(http.StatusOK)
+		case <-c.Request.Context().Done():
+		}
+	}
+}
+
+func TestModule_RouteTimeouts(t *testing.T) {
+	c := core.NewContainer()
+	root := testRoot()
+	root.Server.RequestTimeout = 20 * time.Millisecond
+	root.Server.RouteTimeouts = map[string]time.Duration{"GET /reports": time.Second}
+	core.Put(c, root)
+	core.Put(c, testLogger())
+
+	handler := slowHandler(100 * time.Millisecond)
+	mod := Module(WithRoutes(func(r Router) {
+		r.GET("/reports", handler)
+		r.GET("/orders", handler)
+	}))
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := Engine(c)
+
+	if w := serve(engine, http.MethodGet, "/reports"); w.Code != http.StatusOK {
+		t.Errorf("GET /reports = %d, want %d", w.Code, http.StatusOK)
+	}
+	w := serve(engine, http.MethodGet, "/orders")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /orders = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if p := decodeProblem(t, w); p.Detail != "request timed out after 20ms" {
+		t.Errorf("detail = %q", p.Detail)
+	}
+}
+
+func TestModule_RouteTimeoutsReload(t *testing.T) {
+	overrides := &source.Override{}
+	overrides.Set("app.name", "test")
+	overrides.Set("app.version", "0.0.0")
+	overrides.Set("server.addr", "127.0.0.1:0")
+	overrides.Set("server.routeTimeouts.GET /orders", "20ms")
+
+	var cfg config.Root
+	mgr, err := config.NewManager(&cfg, config.Options{}, overrides)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	defer mgr.Close()
+
+	c := core.NewContainer()
+	core.Put(c, cfg)
+	core.Put(c, mgr)
+	core.Put(c, testLogger())
+	mod := Module(WithRoutes(func(r Router) {
+		r.GET("/orders", slowHandler(100*time.Millisecond))
+	}))
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	engine := Engine(c)
+
+	if w := serve(engine, http.MethodGet, "/orders"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /orders = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	overrides.Set("server.routeTimeouts.GET /orders", "1s")
+	if err := mgr.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		w := serve(engine, http.MethodGet, "/orders")
+		if w.Code == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GET /orders after reload = %d, want %d", w.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/fast", Timeout(time.Second), slowHandler(time.Millisecond))
+	r.GET("/slow", Timeout(10*time.Millisecond), slowHandler(time.Second))
+	r.GET("/unbounded", Timeout(0), slowHandler(time.Millisecond))
+
+	for path, want := range map[string]int{
+		"/fast":      http.StatusOK,
+		"/slow":      http.StatusServiceUnavailable,
+		"/unbounded": http.StatusOK,
+	} {
+		if w := serve(r, http.MethodGet, path); w.Code != want {
+			t.Errorf("GET %s = %d, want %d", path, w.Code, want)
+		}
+	}
+}
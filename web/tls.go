@@ -0,0 +1,88 @@
+package web
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// certCheckInterval bounds how often the certificate files are checked for
+// changes during handshakes.
+const certCheckInterval = time.Second
+
+// certReloader serves a certificate from disk, re-reading it when the cert
+// or key file changes, so rotated certificates (e.g. Let's Encrypt
+// renewals) apply to new connections without a restart.
+type certReloader struct {
+	certFile, keyFile string
+	checkInterval     time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+}
+
+// newCertReloader loads the initial certificate, failing if it's invalid.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, checkInterval: certCheckInterval}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. If the files changed
+// but the new pair fails to load (e.g. mid-rotation), the previous
+// certificate keeps being served.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.lastCheck) >= r.checkInterval {
+		r.lastCheck = time.Now()
+		if r.changed() {
+			_ = r.reloadLocked()
+		}
+	}
+	return r.cert, nil
+}
+
+func (r *certReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reloadLocked()
+}
+
+func (r *certReloader) reloadLocked() error {
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tls: load certificate: %w", err)
+	}
+	r.cert, r.certMod, r.keyMod = &cert, certMod, keyMod
+	return nil
+}
+
+func (r *certReloader) changed() bool {
+	certMod, keyMod, err := r.modTimes()
+	return err == nil && (!certMod.Equal(r.certMod) || !keyMod.Equal(r.keyMod))
+}
+
+func (r *certReloader) modTimes() (certMod, keyMod time.Time, err error) {
+	ci, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("tls: %w", err)
+	}
+	ki, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("tls: %w", err)
+	}
+	return ci.ModTime(), ki.ModTime(), nil
+}
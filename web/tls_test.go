@@ -0,0 +1,132 @@
+package web
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skekre98/genever/core"
+)
+
+// writeCert writes a self-signed certificate for commonName to dir and
+// bumps the files' mtime to modTime.
+func writeCert(t *testing.T, dir, commonName string, modTime time.Time) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return certFile, keyFile
+}
+
+// servedCommonName dials addr and returns the CommonName of the server's
+// certificate.
+func servedCommonName(t *testing.T, addr string) string {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("tls.Dial() error = %v", err)
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestModule_TLSCertificateRotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	certFile, keyFile := writeCert(t, dir, "original", now.Add(-time.Minute))
+
+	root := testRoot()
+	root.Server.TLS.Enabled = true
+	root.Server.TLS.CertFile = certFile
+	root.Server.TLS.KeyFile = keyFile
+
+	c := core.NewContainer()
+	core.Put(c, root)
+	core.Put(c, testLogger())
+	group := core.NewGroup(context.Background())
+	core.Put(c, group)
+
+	mod := Module().(*webModule)
+	if err := mod.Configure(c); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := mod.Start(context.Background(), c); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer func() {
+		mod.Stop(context.Background(), c)
+		group.Wait()
+	}()
+	addr := mod.listener.Addr().String()
+
+	if got := servedCommonName(t, addr); got != "original" {
+		t.Fatalf("served certificate = %q, want %q", got, "original")
+	}
+
+	writeCert(t, dir, "rotated", now)
+	deadline := time.Now().Add(3 * certCheckInterval)
+	for {
+		got := servedCommonName(t, addr)
+		if got == "rotated" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("served certificate = %q after rotation, want %q", got, "rotated")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestModule_TLSInvalidCertificate(t *testing.T) {
+	root := testRoot()
+	root.Server.TLS.Enabled = true
+	root.Server.TLS.CertFile = filepath.Join(t.TempDir(), "missing.crt")
+	root.Server.TLS.KeyFile = filepath.Join(t.TempDir(), "missing.key")
+
+	c := core.NewContainer()
+	core.Put(c, root)
+	core.Put(c, testLogger())
+	if err := Module().Configure(c); err == nil {
+		t.Fatal("Configure() with missing certificate: expected error")
+	}
+}
@@ -0,0 +1,32 @@
+package web
+
+// valueKey is the gin context key of the value of type T stored by
+// SetValue. Being unexported, it can't collide with string keys or with
+// keys of other packages.
+type valueKey[T any] struct{}
+
+// SetValue stores v in the request context c under its type, for handlers
+// later in the chain to read with GetValue. A later SetValue of the same
+// type replaces it. Use a named type to store several values of the same
+// underlying type:
+//
+//	type TenantID string
+//
+//	web.SetValue(c, TenantID(c.GetHeader("X-Tenant")))
+//	...
+//	tenant, ok := web.GetValue[TenantID](c)
+func SetValue[T any](c Ctx, v T) {
+	c.Set(valueKey[T]{}, v)
+}
+
+// GetValue returns the value of type T stored with SetValue for this
+// request, or false if there is none.
+func GetValue[T any](c Ctx) (T, bool) {
+	v, ok := c.Get(valueKey[T]{})
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	t, ok := v.(T)
+	return t, ok
+}
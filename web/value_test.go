@@ -0,0 +1,35 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestValue(t *testing.T) {
+	type TenantID string
+	type user struct{ Name string }
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if _, ok := GetValue[TenantID](c); ok {
+		t.Error("GetValue() before SetValue: ok = true")
+	}
+
+	SetValue(c, TenantID("acme"))
+	SetValue(c, &user{Name: "ada"})
+	c.Set("tenant", "not a TenantID")
+
+	if got, ok := GetValue[TenantID](c); !ok || got != "acme" {
+		t.Errorf("GetValue[TenantID]() = %q, %v, want acme", got, ok)
+	}
+	if got, ok := GetValue[*user](c); !ok || got.Name != "ada" {
+		t.Errorf("GetValue[*user]() = %v, %v", got, ok)
+	}
+	if _, ok := GetValue[string](c); ok {
+		t.Error("GetValue[string]() ok = true, want a miss: types are distinct keys")
+	}
+}